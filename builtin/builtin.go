@@ -0,0 +1,100 @@
+// Package builtin provide standard functions for evaluate visitor
+//
+// Usage:
+//
+//	registry := builtin.NewRegistry()
+//	if err := registry.Register(custom); err != nil {
+//		// handle error
+//	}
+//	v := evaluate.NewVisitor(args, evaluate.WithRegistry(registry))
+package builtin
+
+import (
+	"github.com/haunt98/evaluator/evaluate"
+)
+
+// NewRegistry return registry of all builtin functions
+// Register more functions to extend it
+func NewRegistry() *evaluate.Registry {
+	registry := evaluate.NewRegistry()
+	if err := registry.Register(Functions()...); err != nil {
+		// builtin functions are valid, see TestFunctions
+		panic(err)
+	}
+
+	return registry
+}
+
+// Functions return all builtin functions
+func Functions() []evaluate.Function {
+	return []evaluate.Function{
+		{
+			Name:   "contains",
+			Params: []evaluate.Kind{evaluate.StringKind, evaluate.StringKind},
+			Result: evaluate.BoolKind,
+			Fn:     contains,
+		},
+		{
+			Name:   "startsWith",
+			Params: []evaluate.Kind{evaluate.StringKind, evaluate.StringKind},
+			Result: evaluate.BoolKind,
+			Fn:     startsWith,
+		},
+		{
+			Name:   "endsWith",
+			Params: []evaluate.Kind{evaluate.StringKind, evaluate.StringKind},
+			Result: evaluate.BoolKind,
+			Fn:     endsWith,
+		},
+		{
+			Name:   "lower",
+			Params: []evaluate.Kind{evaluate.StringKind},
+			Result: evaluate.StringKind,
+			Fn:     lower,
+		},
+		{
+			Name:   "upper",
+			Params: []evaluate.Kind{evaluate.StringKind},
+			Result: evaluate.StringKind,
+			Fn:     upper,
+		},
+		{
+			Name:   "trim",
+			Params: []evaluate.Kind{evaluate.StringKind},
+			Result: evaluate.StringKind,
+			Fn:     trim,
+		},
+		{
+			Name:   "len",
+			Params: []evaluate.Kind{evaluate.AnyKind},
+			Result: evaluate.IntKind,
+			Fn:     length,
+		},
+		{
+			// substring(s, start) or substring(s, start, end)
+			Name:     "substring",
+			Params:   []evaluate.Kind{evaluate.StringKind, evaluate.IntKind, evaluate.IntKind},
+			Optional: 1,
+			Result:   evaluate.StringKind,
+			Fn:       substring,
+		},
+		{
+			Name:   "replace",
+			Params: []evaluate.Kind{evaluate.StringKind, evaluate.StringKind, evaluate.StringKind},
+			Result: evaluate.StringKind,
+			Fn:     replace,
+		},
+		{
+			Name:   "split",
+			Params: []evaluate.Kind{evaluate.StringKind, evaluate.StringKind},
+			Result: evaluate.ArrayKind,
+			Fn:     split,
+		},
+		{
+			Name:   "matches",
+			Params: []evaluate.Kind{evaluate.StringKind, evaluate.StringKind},
+			Result: evaluate.BoolKind,
+			Fn:     matches,
+		},
+	}
+}
@@ -0,0 +1,235 @@
+package builtin
+
+import (
+	"testing"
+
+	"github.com/haunt98/evaluator/evaluate"
+	"github.com/haunt98/evaluator/expression"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCase struct {
+	name       string
+	inputExpr  expression.Expression
+	inputArgs  map[string]interface{}
+	wantResult expression.Expression
+	wantErr    bool
+}
+
+func generateTestCaseString() []testCase {
+	return []testCase{
+		{
+			name: "contains",
+			inputExpr: expression.NewCallExpression("contains",
+				expression.NewStringLiteral("abc"),
+				expression.NewStringLiteral("b"),
+			),
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name: "contains",
+			inputExpr: expression.NewCallExpression("contains",
+				expression.NewStringLiteral("abc"),
+				expression.NewStringLiteral("d"),
+			),
+			wantResult: expression.NewBoolLiteral(false),
+		},
+		{
+			name: "startsWith",
+			inputExpr: expression.NewCallExpression("startsWith",
+				expression.NewVarExpression("x"),
+				expression.NewStringLiteral("ab"),
+			),
+			inputArgs: map[string]interface{}{
+				"x": "abc",
+			},
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name: "endsWith",
+			inputExpr: expression.NewCallExpression("endsWith",
+				expression.NewStringLiteral("abc"),
+				expression.NewStringLiteral("ab"),
+			),
+			wantResult: expression.NewBoolLiteral(false),
+		},
+		{
+			name:       "lower",
+			inputExpr:  expression.NewCallExpression("lower", expression.NewStringLiteral("VN")),
+			wantResult: expression.NewStringLiteral("vn"),
+		},
+		{
+			name:       "upper",
+			inputExpr:  expression.NewCallExpression("upper", expression.NewStringLiteral("vn")),
+			wantResult: expression.NewStringLiteral("VN"),
+		},
+		{
+			name:       "trim",
+			inputExpr:  expression.NewCallExpression("trim", expression.NewStringLiteral(" a b ")),
+			wantResult: expression.NewStringLiteral("a b"),
+		},
+		{
+			name: "replace",
+			inputExpr: expression.NewCallExpression("replace",
+				expression.NewStringLiteral("a-b-c"),
+				expression.NewStringLiteral("-"),
+				expression.NewStringLiteral("+"),
+			),
+			wantResult: expression.NewStringLiteral("a+b+c"),
+		},
+		{
+			name: "split",
+			inputExpr: expression.NewCallExpression("split",
+				expression.NewStringLiteral("a,b"),
+				expression.NewStringLiteral(","),
+			),
+			wantResult: expression.NewArrayExpression(
+				expression.NewStringLiteral("a"),
+				expression.NewStringLiteral("b"),
+			),
+		},
+		{
+			name: "wrong kind of arg",
+			inputExpr: expression.NewCallExpression("contains",
+				expression.NewIntLiteral(1),
+				expression.NewStringLiteral("b"),
+			),
+			wantErr: true,
+		},
+		{
+			name: "wrong number of args",
+			inputExpr: expression.NewCallExpression("lower",
+				expression.NewStringLiteral("a"),
+				expression.NewStringLiteral("b"),
+			),
+			wantErr: true,
+		},
+	}
+}
+
+func generateTestCaseLen() []testCase {
+	return []testCase{
+		{
+			name:       "len string",
+			inputExpr:  expression.NewCallExpression("len", expression.NewStringLiteral("việt")),
+			wantResult: expression.NewIntLiteral(4),
+		},
+		{
+			name: "len array",
+			inputExpr: expression.NewCallExpression("len", expression.NewArrayExpression(
+				expression.NewIntLiteral(1),
+				expression.NewIntLiteral(2),
+				expression.NewIntLiteral(3),
+			)),
+			wantResult: expression.NewIntLiteral(3),
+		},
+		{
+			name:      "len int",
+			inputExpr: expression.NewCallExpression("len", expression.NewIntLiteral(1)),
+			wantErr:   true,
+		},
+	}
+}
+
+func generateTestCaseSubstring() []testCase {
+	return []testCase{
+		{
+			name: "substring start",
+			inputExpr: expression.NewCallExpression("substring",
+				expression.NewStringLiteral("việt nam"),
+				expression.NewIntLiteral(5),
+			),
+			wantResult: expression.NewStringLiteral("nam"),
+		},
+		{
+			name: "substring start end",
+			inputExpr: expression.NewCallExpression("substring",
+				expression.NewStringLiteral("việt nam"),
+				expression.NewIntLiteral(0),
+				expression.NewIntLiteral(4),
+			),
+			wantResult: expression.NewStringLiteral("việt"),
+		},
+		{
+			name: "substring out of range",
+			inputExpr: expression.NewCallExpression("substring",
+				expression.NewStringLiteral("abc"),
+				expression.NewIntLiteral(1),
+				expression.NewIntLiteral(4),
+			),
+			wantErr: true,
+		},
+		{
+			name: "substring start greater than end",
+			inputExpr: expression.NewCallExpression("substring",
+				expression.NewStringLiteral("abc"),
+				expression.NewIntLiteral(2),
+				expression.NewIntLiteral(1),
+			),
+			wantErr: true,
+		},
+		{
+			name: "substring too many args",
+			inputExpr: expression.NewCallExpression("substring",
+				expression.NewStringLiteral("abc"),
+				expression.NewIntLiteral(0),
+				expression.NewIntLiteral(1),
+				expression.NewIntLiteral(2),
+			),
+			wantErr: true,
+		},
+	}
+}
+
+func generateTestCaseMatches() []testCase {
+	return []testCase{
+		{
+			name: "matches",
+			inputExpr: expression.NewCallExpression("matches",
+				expression.NewStringLiteral("abc123"),
+				expression.NewStringLiteral("^[a-z]+[0-9]+$"),
+			),
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name: "matches cached pattern",
+			inputExpr: expression.NewCallExpression("matches",
+				expression.NewStringLiteral("123abc"),
+				expression.NewStringLiteral("^[a-z]+[0-9]+$"),
+			),
+			wantResult: expression.NewBoolLiteral(false),
+		},
+		{
+			name: "matches invalid pattern",
+			inputExpr: expression.NewCallExpression("matches",
+				expression.NewStringLiteral("abc"),
+				expression.NewStringLiteral("("),
+			),
+			wantErr: true,
+		},
+	}
+}
+
+func TestFunctions(t *testing.T) {
+	registry := NewRegistry()
+
+	var tests []testCase
+	tests = append(tests, generateTestCaseString()...)
+	tests = append(tests, generateTestCaseLen()...)
+	tests = append(tests, generateTestCaseSubstring()...)
+	tests = append(tests, generateTestCaseMatches()...)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := evaluate.NewVisitor(tc.inputArgs, evaluate.WithRegistry(registry))
+
+			gotResult, gotErr := v.Visit(tc.inputExpr)
+			if tc.wantErr {
+				assert.Error(t, gotErr)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tc.wantResult, gotResult)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package builtin
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/haunt98/evaluator/internal/lru"
+)
+
+// maxRegexps is max number of compiled patterns which are cached
+// pattern may come from args so cache must be bounded
+const maxRegexps = 256
+
+// regexpCache is LRU cache of compiled pattern, it is safe for concurrent use
+type regexpCache struct {
+	lru *lru.Cache
+}
+
+func newRegexpCache(size int) *regexpCache {
+	return &regexpCache{
+		lru: lru.New(size, 0, time.Now),
+	}
+}
+
+// compile return cached pattern, compile it if it is not cached
+// Error is not cached
+func (c *regexpCache) compile(pattern string) (*regexp.Regexp, error) {
+	if re, ok := c.lru.Get(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	// compile without lock so other patterns are not blocked
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.lru.Add(pattern, re).(*regexp.Regexp), nil
+}
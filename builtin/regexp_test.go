@@ -0,0 +1,38 @@
+package builtin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegexpCacheBounded(t *testing.T) {
+	c := newRegexpCache(2)
+
+	for i := 0; i < 10; i++ {
+		_, err := c.compile(fmt.Sprintf("a%d", i))
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, c.lru.Len())
+}
+
+func TestRegexpCacheSame(t *testing.T) {
+	c := newRegexpCache(2)
+
+	first, err := c.compile("a")
+	assert.NoError(t, err)
+
+	got, err := c.compile("a")
+	assert.NoError(t, err)
+	assert.Same(t, first, got)
+}
+
+func TestRegexpCacheError(t *testing.T) {
+	c := newRegexpCache(2)
+
+	_, err := c.compile("(")
+	assert.Error(t, err)
+	assert.Equal(t, 0, c.lru.Len())
+}
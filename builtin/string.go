@@ -0,0 +1,89 @@
+package builtin
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// regexps cache compiled pattern of matches
+var regexps = newRegexpCache(maxRegexps)
+
+func contains(args ...interface{}) (interface{}, error) {
+	return strings.Contains(args[0].(string), args[1].(string)), nil
+}
+
+func startsWith(args ...interface{}) (interface{}, error) {
+	return strings.HasPrefix(args[0].(string), args[1].(string)), nil
+}
+
+func endsWith(args ...interface{}) (interface{}, error) {
+	return strings.HasSuffix(args[0].(string), args[1].(string)), nil
+}
+
+func lower(args ...interface{}) (interface{}, error) {
+	return strings.ToLower(args[0].(string)), nil
+}
+
+func upper(args ...interface{}) (interface{}, error) {
+	return strings.ToUpper(args[0].(string)), nil
+}
+
+func trim(args ...interface{}) (interface{}, error) {
+	return strings.TrimSpace(args[0].(string)), nil
+}
+
+// length return number of characters of string or number of items of array
+func length(args ...interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case string:
+		return int64(utf8.RuneCountInString(v)), nil
+	case []interface{}:
+		return int64(len(v)), nil
+	default:
+		return nil, fmt.Errorf("expect string or array got %T", v)
+	}
+}
+
+// substring return characters from start to end (exclusive)
+// end is length of string if missing
+func substring(args ...interface{}) (interface{}, error) {
+	runes := []rune(args[0].(string))
+	start := args[1].(int64)
+	end := int64(len(runes))
+	if len(args) == 3 {
+		end = args[2].(int64)
+	}
+
+	if start < 0 || end > int64(len(runes)) || start > end {
+		return nil, fmt.Errorf("index out of range start %d end %d length %d", start, end, len(runes))
+	}
+
+	return string(runes[start:end]), nil
+}
+
+func replace(args ...interface{}) (interface{}, error) {
+	return strings.ReplaceAll(args[0].(string), args[1].(string), args[2].(string)), nil
+}
+
+func split(args ...interface{}) (interface{}, error) {
+	parts := strings.Split(args[0].(string), args[1].(string))
+
+	result := make([]interface{}, len(parts))
+	for i, part := range parts {
+		result[i] = part
+	}
+
+	return result, nil
+}
+
+func matches(args ...interface{}) (interface{}, error) {
+	pattern := args[1].(string)
+
+	re, err := regexps.compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile pattern %s: %w", pattern, err)
+	}
+
+	return re.MatchString(args[0].(string)), nil
+}
@@ -0,0 +1,113 @@
+// Package cache store parsed expression by input
+// so the same input is only parsed once
+package cache
+
+import (
+	"time"
+
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/internal/lru"
+	"github.com/haunt98/evaluator/optimize"
+	"github.com/haunt98/evaluator/parser"
+)
+
+const defaultSize = 1024
+
+// Cache is LRU cache of parsed expression, it is safe for concurrent use
+// Expression is shared between callers so it must not be changed
+type Cache struct {
+	size       int
+	ttl        time.Duration
+	isOptimize bool
+	now        func() time.Time
+
+	lru *lru.Cache
+}
+
+// Stats is counters of cache
+// Evictions count entries which are removed because cache is full or entry is expired
+type Stats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+}
+
+type Option func(c *Cache)
+
+// WithSize set max number of entries, default is 1024
+// Size <= 0 means cache is unbounded, entries are only removed when expired
+func WithSize(size int) Option {
+	return func(c *Cache) {
+		c.size = size
+	}
+}
+
+// WithTTL set time to live of entry, default is no expiration
+func WithTTL(ttl time.Duration) Option {
+	return func(c *Cache) {
+		c.ttl = ttl
+	}
+}
+
+// WithOptimize store optimized expression instead of parsed expression
+func WithOptimize() Option {
+	return func(c *Cache) {
+		c.isOptimize = true
+	}
+}
+
+func NewCache(opts ...Option) *Cache {
+	c := &Cache{
+		size: defaultSize,
+		now:  time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.lru = lru.New(c.size, c.ttl, func() time.Time {
+		return c.now()
+	})
+
+	return c
+}
+
+// Parse return cached expression of input, parse it if it is not cached
+// Error is not cached so invalid input is parsed again
+func (c *Cache) Parse(input string) (expression.Expression, error) {
+	if expr, ok := c.lru.Get(input); ok {
+		return expr.(expression.Expression), nil
+	}
+
+	// parse without lock so other inputs are not blocked
+	// same input may be parsed concurrently, the first one is kept
+	expr, err := c.parse(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.lru.Add(input, expr).(expression.Expression), nil
+}
+
+func (c *Cache) parse(input string) (expression.Expression, error) {
+	expr, err := parser.NewParser(input).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.isOptimize {
+		return expr, nil
+	}
+
+	return optimize.Optimize(expr)
+}
+
+// Len return number of entries, expired entries are counted until they are removed
+func (c *Cache) Len() int {
+	return c.lru.Len()
+}
+
+func (c *Cache) Stats() Stats {
+	return Stats(c.lru.Stats())
+}
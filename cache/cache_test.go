@@ -0,0 +1,133 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheParse(t *testing.T) {
+	c := NewCache()
+
+	expr1, err := c.Parse("$x == 1")
+	assert.NoError(t, err)
+
+	expr2, err := c.Parse("$x == 1")
+	assert.NoError(t, err)
+	assert.Same(t, expr1, expr2)
+
+	_, err = c.Parse("$x ==")
+	assert.Error(t, err)
+	_, err = c.Parse("$x ==")
+	assert.Error(t, err)
+
+	assert.Equal(t, 1, c.Len())
+	assert.Equal(t, Stats{
+		Hits:   1,
+		Misses: 3,
+	}, c.Stats())
+}
+
+func TestCacheEvictLeastRecentlyUsed(t *testing.T) {
+	c := NewCache(WithSize(2))
+
+	a, err := c.Parse("$a")
+	assert.NoError(t, err)
+	_, err = c.Parse("$b")
+	assert.NoError(t, err)
+
+	// $a is used so $b is evicted
+	_, err = c.Parse("$a")
+	assert.NoError(t, err)
+	_, err = c.Parse("$c")
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, c.Len())
+
+	gotA, err := c.Parse("$a")
+	assert.NoError(t, err)
+	assert.Same(t, a, gotA)
+
+	_, err = c.Parse("$b")
+	assert.NoError(t, err)
+
+	assert.Equal(t, Stats{
+		Hits:      2,
+		Misses:    4,
+		Evictions: 2,
+	}, c.Stats())
+}
+
+func TestCacheTTL(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c := NewCache(WithTTL(time.Minute))
+	c.now = func() time.Time {
+		return now
+	}
+
+	expr1, err := c.Parse("$a")
+	assert.NoError(t, err)
+
+	now = now.Add(59 * time.Second)
+	expr2, err := c.Parse("$a")
+	assert.NoError(t, err)
+	assert.Same(t, expr1, expr2)
+
+	now = now.Add(time.Second)
+	expr3, err := c.Parse("$a")
+	assert.NoError(t, err)
+	assert.NotSame(t, expr1, expr3)
+
+	assert.Equal(t, Stats{
+		Hits:      1,
+		Misses:    2,
+		Evictions: 1,
+	}, c.Stats())
+}
+
+func TestCacheOptimize(t *testing.T) {
+	c := NewCache(WithOptimize())
+
+	expr, err := c.Parse("true and $x > 1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Varx > 1", expr.String())
+}
+
+func TestCacheUnbounded(t *testing.T) {
+	c := NewCache(WithSize(0))
+
+	for _, input := range []string{"$a", "$b", "$c"} {
+		_, err := c.Parse(input)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 3, c.Len())
+	assert.Equal(t, int64(0), c.Stats().Evictions)
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	c := NewCache(WithSize(5))
+
+	inputs := []string{"$a", "$b", "$c", "$d", "$e", "$f", "$g"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				_, err := c.Parse(inputs[j%len(inputs)])
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := c.Stats()
+	assert.Equal(t, int64(1000), stats.Hits+stats.Misses)
+	assert.LessOrEqual(t, c.Len(), 5)
+}
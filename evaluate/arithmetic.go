@@ -0,0 +1,86 @@
+package evaluate
+
+import (
+	"errors"
+
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/internal/number"
+)
+
+func (v *visitor) visitArithmetic(expr *expression.BinaryExpression) (expression.Expression, error) {
+	left, err := v.Visit(expr.Left)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := v.Visit(expr.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := arithmeticNumber(expr, left, right)
+	switch {
+	case errors.Is(err, number.ErrDivisionByZero):
+		return nil, &DivisionByZeroError{
+			Expr:  expr,
+			Left:  left,
+			Right: right,
+		}
+	case errors.Is(err, number.ErrOverflow):
+		return nil, &OverflowError{
+			Expr:     expr,
+			Operator: expr.Operator,
+			Operands: []expression.Expression{left, right},
+		}
+	}
+
+	return result, err
+}
+
+// arithmeticNumber calculate left op right using operator of expr
+// int with int is int, return error if overflow
+// int with float is float
+func arithmeticNumber(expr *expression.BinaryExpression, left, right expression.Expression) (expression.Expression, error) {
+	op := expr.Operator
+
+	switch l := left.(type) {
+	case *expression.IntLiteral:
+		switch r := right.(type) {
+		case *expression.IntLiteral:
+			result, err := number.ArithmeticInt(op, l.Value, r.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			return expression.NewIntLiteral(result), nil
+		case *expression.FloatLiteral:
+			result, err := number.ArithmeticFloat(op, float64(l.Value), r.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			return expression.NewFloatLiteral(result), nil
+		default:
+			return nil, newNumberMismatchError(expr, right)
+		}
+	case *expression.FloatLiteral:
+		var rightValue float64
+		switch r := right.(type) {
+		case *expression.IntLiteral:
+			rightValue = float64(r.Value)
+		case *expression.FloatLiteral:
+			rightValue = r.Value
+		default:
+			return nil, newNumberMismatchError(expr, right)
+		}
+
+		result, err := number.ArithmeticFloat(op, l.Value, rightValue)
+		if err != nil {
+			return nil, err
+		}
+
+		return expression.NewFloatLiteral(result), nil
+	default:
+		return nil, newNumberMismatchError(expr, left)
+	}
+}
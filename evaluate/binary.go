@@ -1,8 +1,6 @@
 package evaluate
 
 import (
-	"fmt"
-
 	"github.com/haunt98/evaluator/expression"
 	"github.com/haunt98/evaluator/token"
 )
@@ -15,7 +13,11 @@ func (v *visitor) visitOr(expr *expression.BinaryExpression) (expression.Express
 
 	leftLit, ok := left.(*expression.BoolLiteral)
 	if !ok {
-		return nil, fmt.Errorf("expect bool literal got %s", left)
+		return nil, &TypeMismatchError{
+			Expr:     expr,
+			Expected: "bool literal",
+			Got:      left,
+		}
 	}
 
 	// true or any -> true
@@ -30,7 +32,11 @@ func (v *visitor) visitOr(expr *expression.BinaryExpression) (expression.Express
 
 	rightLit, ok := right.(*expression.BoolLiteral)
 	if !ok {
-		return nil, fmt.Errorf("expect bool literal got %s", right)
+		return nil, &TypeMismatchError{
+			Expr:     expr,
+			Expected: "bool literal",
+			Got:      right,
+		}
 	}
 
 	return rightLit, nil
@@ -44,7 +50,11 @@ func (v *visitor) visitAnd(expr *expression.BinaryExpression) (expression.Expres
 
 	leftLit, ok := left.(*expression.BoolLiteral)
 	if !ok {
-		return nil, fmt.Errorf("expect bool literal got %s", left)
+		return nil, &TypeMismatchError{
+			Expr:     expr,
+			Expected: "bool literal",
+			Got:      left,
+		}
 	}
 
 	// false and any -> false
@@ -59,7 +69,11 @@ func (v *visitor) visitAnd(expr *expression.BinaryExpression) (expression.Expres
 
 	rightLit, ok := right.(*expression.BoolLiteral)
 	if !ok {
-		return nil, fmt.Errorf("expect bool literal got %s", right)
+		return nil, &TypeMismatchError{
+			Expr:     expr,
+			Expected: "bool literal",
+			Got:      right,
+		}
 	}
 
 	return rightLit, nil
@@ -76,6 +90,11 @@ func (v *visitor) visitEqual(expr *expression.BinaryExpression) (expression.Expr
 		return nil, err
 	}
 
+	// null is only equal to null
+	if isNull(left) || isNull(right) {
+		return expression.NewBoolLiteral(isNull(left) && isNull(right)), nil
+	}
+
 	// TODO: handle more types
 	switch l := left.(type) {
 	case *expression.BoolLiteral:
@@ -83,24 +102,36 @@ func (v *visitor) visitEqual(expr *expression.BinaryExpression) (expression.Expr
 		case *expression.BoolLiteral:
 			return expression.NewBoolLiteral(l.Value == r.Value), nil
 		default:
-			return nil, fmt.Errorf("expect bool literal got %T", r)
+			return nil, &TypeMismatchError{
+				Expr:     expr,
+				Expected: "bool literal",
+				Got:      r,
+			}
 		}
-	case *expression.IntLiteral:
-		switch r := right.(type) {
-		case *expression.IntLiteral:
-			return expression.NewBoolLiteral(l.Value == r.Value), nil
-		default:
-			return nil, fmt.Errorf("expect int literal got %T", r)
+	case *expression.IntLiteral, *expression.FloatLiteral:
+		result, err := compareNumber(token.Equal, expr, left, right)
+		if err != nil {
+			return nil, err
 		}
+
+		return expression.NewBoolLiteral(result), nil
 	case *expression.StringLiteral:
 		switch r := right.(type) {
 		case *expression.StringLiteral:
 			return expression.NewBoolLiteral(l.Value == r.Value), nil
 		default:
-			return nil, fmt.Errorf("expect string literal got %T", r)
+			return nil, &TypeMismatchError{
+				Expr:     expr,
+				Expected: "string literal",
+				Got:      r,
+			}
 		}
 	default:
-		return nil, fmt.Errorf("not implement visit equal %T", l)
+		return nil, &TypeMismatchError{
+			Expr:     expr,
+			Expected: "bool, int, float or string literal",
+			Got:      l,
+		}
 	}
 }
 
@@ -112,142 +143,103 @@ func (v *visitor) visitNotEqual(expr *expression.BinaryExpression) (expression.E
 
 	equalLit, ok := equalExpr.(*expression.BoolLiteral)
 	if !ok {
-		return nil, fmt.Errorf("export bool literal got %s", equalExpr)
+		return nil, &TypeMismatchError{
+			Expr:     expr,
+			Expected: "bool literal",
+			Got:      equalExpr,
+		}
 	}
 
 	return expression.NewBoolLiteral(!equalLit.Value), nil
 }
 
-func (v *visitor) visitLess(expr *expression.BinaryExpression) (expression.Expression, error) {
+func (v *visitor) visitCompare(expr *expression.BinaryExpression) (expression.Expression, error) {
 	left, err := v.Visit(expr.Left)
 	if err != nil {
 		return nil, err
 	}
 
-	leftLit, ok := left.(*expression.IntLiteral)
-	if !ok {
-		return nil, fmt.Errorf("expect int literal got %s", left)
-	}
-
 	right, err := v.Visit(expr.Right)
 	if err != nil {
 		return nil, err
 	}
 
-	rightLit, ok := right.(*expression.IntLiteral)
-	if !ok {
-		return nil, fmt.Errorf("expect int literal got %s", right)
+	// null is neither less nor greater than anything
+	if isNull(left) || isNull(right) {
+		return expression.NewBoolLiteral(false), nil
 	}
 
-	return expression.NewBoolLiteral(leftLit.Value < rightLit.Value), nil
-}
-
-func (v *visitor) visitLessOrEqual(expr *expression.BinaryExpression) (expression.Expression, error) {
-	left, err := v.Visit(expr.Left)
+	result, err := compareNumber(expr.Operator, expr, left, right)
 	if err != nil {
 		return nil, err
 	}
 
-	leftLit, ok := left.(*expression.IntLiteral)
-	if !ok {
-		return nil, fmt.Errorf("expect int literal got %s", left)
-	}
-
-	right, err := v.Visit(expr.Right)
-	if err != nil {
-		return nil, err
-	}
-
-	rightLit, ok := right.(*expression.IntLiteral)
-	if !ok {
-		return nil, fmt.Errorf("expect int literal got %s", right)
-	}
-
-	return expression.NewBoolLiteral(leftLit.Value <= rightLit.Value), nil
+	return expression.NewBoolLiteral(result), nil
 }
 
-func (v *visitor) visitGreater(expr *expression.BinaryExpression) (expression.Expression, error) {
+func (v *visitor) visitIn(expr *expression.BinaryExpression) (expression.Expression, error) {
 	left, err := v.Visit(expr.Left)
 	if err != nil {
 		return nil, err
 	}
 
-	leftLit, ok := left.(*expression.IntLiteral)
-	if !ok {
-		return nil, fmt.Errorf("expect int literal got %s", left)
-	}
-
 	right, err := v.Visit(expr.Right)
 	if err != nil {
 		return nil, err
 	}
 
-	rightLit, ok := right.(*expression.IntLiteral)
-	if !ok {
-		return nil, fmt.Errorf("expect int literal got %s", right)
-	}
-
-	return expression.NewBoolLiteral(leftLit.Value > rightLit.Value), nil
-}
-
-func (v *visitor) visitGreaterOrEqual(expr *expression.BinaryExpression) (expression.Expression, error) {
-	left, err := v.Visit(expr.Left)
-	if err != nil {
-		return nil, err
-	}
-
-	leftLit, ok := left.(*expression.IntLiteral)
-	if !ok {
-		return nil, fmt.Errorf("expect int literal got %s", left)
+	// null is empty array
+	if isNull(right) {
+		return expression.NewBoolLiteral(false), nil
 	}
 
-	right, err := v.Visit(expr.Right)
-	if err != nil {
-		return nil, err
-	}
+	switch r := right.(type) {
+	case *expression.SetLiteral:
+		return expression.NewBoolLiteral(r.Contains(left)), nil
+	case *expression.ArrayExpression:
+		for _, child := range r.Children {
+			if equalLiteral(left, child) {
+				return expression.NewBoolLiteral(true), nil
+			}
+		}
 
-	rightLit, ok := right.(*expression.IntLiteral)
-	if !ok {
-		return nil, fmt.Errorf("expect int literal got %s", right)
+		return expression.NewBoolLiteral(false), nil
+	default:
+		return nil, &TypeMismatchError{
+			Expr:     expr,
+			Expected: "array expression",
+			Got:      right,
+		}
 	}
-
-	return expression.NewBoolLiteral(leftLit.Value >= rightLit.Value), nil
 }
 
-func (v *visitor) visitIn(expr *expression.BinaryExpression) (expression.Expression, error) {
-	left, err := v.Visit(expr.Left)
-	if err != nil {
-		return nil, err
+// equalLiteral return true if left is equal to right, it is the same as visitEqual
+// but literals with different types are not equal instead of error
+// It is also the same as SetLiteral.Contains
+func equalLiteral(left, right expression.Expression) bool {
+	if isNull(left) || isNull(right) {
+		return isNull(left) && isNull(right)
 	}
 
-	right, err := v.Visit(expr.Right)
-	if err != nil {
-		return nil, err
-	}
-
-	rightArr, ok := right.(*expression.ArrayExpression)
-	if !ok {
-		return nil, fmt.Errorf("expect array expression got %s", right)
-	}
-
-	// compare left to all children of right
-	for _, child := range rightArr.Children {
-		equalExpr, err := v.visitEqual(expression.NewBinaryExpression(token.Equal, left, child))
-		if err != nil {
-			continue
-		}
-
-		equalLit, ok := equalExpr.(*expression.BoolLiteral)
-		if !ok {
-			continue
-		}
-
-		if equalLit.Value {
-			return expression.NewBoolLiteral(true), nil
+	switch l := left.(type) {
+	case *expression.BoolLiteral:
+		r, ok := right.(*expression.BoolLiteral)
+		return ok && l.Value == r.Value
+	case *expression.IntLiteral, *expression.FloatLiteral:
+		switch right.(type) {
+		case *expression.IntLiteral, *expression.FloatLiteral:
+			// compare number with number never fails
+			result, _ := compareNumber(token.Equal, nil, left, right)
+			return result
+		default:
+			return false
 		}
+	case *expression.StringLiteral:
+		r, ok := right.(*expression.StringLiteral)
+		return ok && l.Value == r.Value
+	default:
+		return false
 	}
-
-	return expression.NewBoolLiteral(false), nil
 }
 
 func (v *visitor) visitNotIn(expr *expression.BinaryExpression) (expression.Expression, error) {
@@ -258,8 +250,17 @@ func (v *visitor) visitNotIn(expr *expression.BinaryExpression) (expression.Expr
 
 	equalLit, ok := equalExpr.(*expression.BoolLiteral)
 	if !ok {
-		return nil, fmt.Errorf("expect bool literal got %s", equalLit)
+		return nil, &TypeMismatchError{
+			Expr:     expr,
+			Expected: "bool literal",
+			Got:      equalExpr,
+		}
 	}
 
 	return expression.NewBoolLiteral(!equalLit.Value), nil
 }
+
+func isNull(expr expression.Expression) bool {
+	_, ok := expr.(*expression.NullLiteral)
+	return ok
+}
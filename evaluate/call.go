@@ -0,0 +1,53 @@
+package evaluate
+
+import (
+	"fmt"
+
+	"github.com/haunt98/evaluator/expression"
+)
+
+func (v *visitor) VisitCall(expr *expression.CallExpression) (expression.Expression, error) {
+	fn, ok := v.registry.Lookup(expr.Name)
+	if !ok {
+		return nil, &UnknownFunctionError{
+			Expr: expr,
+			Name: expr.Name,
+		}
+	}
+
+	args := make([]interface{}, len(expr.Args))
+	for i, arg := range expr.Args {
+		result, err := v.Visit(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := Value(result)
+		if err != nil {
+			return nil, err
+		}
+
+		args[i] = value
+	}
+
+	if err := fn.CheckArgs(args); err != nil {
+		return nil, err
+	}
+
+	value, err := fn.Fn(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call function %s: %w", expr.Name, err)
+	}
+
+	result, err := literal(value)
+	if err != nil {
+		return nil, fmt.Errorf("function %s return: %w", expr.Name, err)
+	}
+
+	// kind of value such as int, []string is known after it is converted to literal
+	if fn.Result != AnyKind && fn.Result != kindOfLiteral(result) {
+		return nil, fmt.Errorf("function %s expect return %s got %s", expr.Name, fn.Result, result)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,107 @@
+package evaluate
+
+import (
+	"fmt"
+
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/internal/access"
+	"github.com/haunt98/evaluator/token"
+)
+
+var (
+	_ error = (*MissingVariableError)(nil)
+	_ error = (*TypeMismatchError)(nil)
+	_ error = (*UnsupportedOperatorError)(nil)
+	_ error = (*DivisionByZeroError)(nil)
+	_ error = (*OverflowError)(nil)
+	_ error = (*UnknownFunctionError)(nil)
+)
+
+// MissingVariableError is returned when var is not in args
+type MissingVariableError struct {
+	Expr *expression.VarExpression
+	Name string
+}
+
+func (e *MissingVariableError) Error() string {
+	return "args missing " + e.Name
+}
+
+// TypeMismatchError is returned when value is not expected type
+// Expr is expression which use the value, Got is the value
+type TypeMismatchError struct {
+	Expr     expression.Expression
+	Expected string
+	Got      expression.Expression
+}
+
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf("expect %s got %s", e.Expected, e.Got)
+}
+
+// UnsupportedOperatorError is returned when operator can not be evaluated
+type UnsupportedOperatorError struct {
+	Expr     expression.Expression
+	Operator token.Token
+}
+
+func (e *UnsupportedOperatorError) Error() string {
+	return fmt.Sprintf("not implement operator %s", e.Operator)
+}
+
+// DivisionByZeroError is returned when right of / or % is zero
+// Left and Right are values of operands
+type DivisionByZeroError struct {
+	Expr  *expression.BinaryExpression
+	Left  expression.Expression
+	Right expression.Expression
+}
+
+func (e *DivisionByZeroError) Error() string {
+	return fmt.Sprintf("division by zero %s %s %s", e.Left, e.Expr.Operator, e.Right)
+}
+
+// OverflowError is returned when int result is out of range of int64
+// Operands are values of operands, there is only one operand if Expr is unary expression
+type OverflowError struct {
+	Expr     expression.Expression
+	Operator token.Token
+	Operands []expression.Expression
+}
+
+func (e *OverflowError) Error() string {
+	if len(e.Operands) == 1 {
+		return fmt.Sprintf("int overflow negate %s", e.Operands[0])
+	}
+
+	return fmt.Sprintf("int overflow %s %s %s", e.Operands[0], e.Operator, e.Operands[1])
+}
+
+// UnknownFunctionError is returned when function is not in registry
+type UnknownFunctionError struct {
+	Expr *expression.CallExpression
+	Name string
+}
+
+func (e *UnknownFunctionError) Error() string {
+	return "not implement function " + e.Name
+}
+
+// Errors of accessing field or index are shared with vm
+// Path is expression in source form such as $user.profile.country
+
+// MissingFieldError is returned when map or struct does not have field
+type MissingFieldError = access.MissingFieldError
+
+// InvalidAccessError is returned when value can not be accessed by field or index
+// such as field of int or field of nil
+type InvalidAccessError = access.InvalidAccessError
+
+// InvalidIndexError is returned when index is not int of slice or not key type of map
+type InvalidIndexError = access.InvalidIndexError
+
+// IndexOutOfRangeError is returned when index is negative or not less than length of slice
+type IndexOutOfRangeError = access.IndexOutOfRangeError
+
+// MissingKeyError is returned when map does not have key
+type MissingKeyError = access.MissingKeyError
@@ -0,0 +1,303 @@
+package evaluate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/token"
+	"github.com/stretchr/testify/assert"
+)
+
+// generateTestCaseError return cases which want typed error
+// vm must return the same typed error, see SharedTestCases
+func generateTestCaseError() []testCase {
+	varX := expression.NewVarExpression("x")
+	notInt := expression.NewUnaryExpression(token.Not, expression.NewIntLiteral(1))
+	divZero := expression.NewBinaryExpression(token.Divide, expression.NewIntLiteral(1), expression.NewIntLiteral(0))
+	modZero := expression.NewBinaryExpression(token.Modulo, expression.NewIntLiteral(1), expression.NewFloatLiteral(0))
+	unsupported := expression.NewUnaryExpression(token.Plus, expression.NewIntLiteral(1))
+	unsupportedBinary := expression.NewBinaryExpression(token.Not, expression.NewIntLiteral(1), expression.NewIntLiteral(1))
+	equalMismatch := expression.NewBinaryExpression(token.Equal, expression.NewStringLiteral("a"), expression.NewIntLiteral(1))
+	compareMismatch := expression.NewBinaryExpression(token.Less, expression.NewIntLiteral(1), expression.NewStringLiteral("a"))
+	arithmeticMismatch := expression.NewBinaryExpression(token.Plus, expression.NewBoolLiteral(true), expression.NewIntLiteral(1))
+	minusMismatch := expression.NewUnaryExpression(token.Minus, expression.NewStringLiteral("a"))
+	inMismatch := expression.NewBinaryExpression(token.In, expression.NewIntLiteral(1), expression.NewIntLiteral(1))
+	indexMismatch := expression.NewIndexExpression(varX, expression.NewStringLiteral("a"))
+	indexOutOfRange := expression.NewIndexExpression(varX, expression.NewIntLiteral(1))
+	unknownFunction := expression.NewCallExpression("unknown", varX)
+	overflow := expression.NewBinaryExpression(token.Multiply, expression.NewIntLiteral(math.MaxInt64), expression.NewIntLiteral(2))
+	negateOverflow := expression.NewUnaryExpression(token.Minus, varX)
+	memberMissing := expression.NewMemberExpression(expression.NewMemberExpression(varX, "a"), "in")
+	memberInvalid := expression.NewMemberExpression(varX, "a")
+
+	return []testCase{
+		{
+			name:      "missing variable",
+			inputExpr: expression.NewBinaryExpression(token.Equal, varX, expression.NewIntLiteral(1)),
+			wantErr: &MissingVariableError{
+				Expr: varX,
+				Name: "x",
+			},
+		},
+		{
+			name:      "type mismatch",
+			inputExpr: notInt,
+			wantErr: &TypeMismatchError{
+				Expr:     notInt,
+				Expected: "bool literal",
+				Got:      expression.NewIntLiteral(1),
+			},
+		},
+		{
+			name:      "type mismatch equal",
+			inputExpr: equalMismatch,
+			wantErr: &TypeMismatchError{
+				Expr:     equalMismatch,
+				Expected: "string literal",
+				Got:      expression.NewIntLiteral(1),
+			},
+		},
+		{
+			name:      "type mismatch compare",
+			inputExpr: compareMismatch,
+			wantErr: &TypeMismatchError{
+				Expr:     compareMismatch,
+				Expected: "int or float literal",
+				Got:      expression.NewStringLiteral("a"),
+			},
+		},
+		{
+			name:      "type mismatch arithmetic",
+			inputExpr: arithmeticMismatch,
+			wantErr: &TypeMismatchError{
+				Expr:     arithmeticMismatch,
+				Expected: "int or float literal",
+				Got:      expression.NewBoolLiteral(true),
+			},
+		},
+		{
+			name:      "type mismatch minus",
+			inputExpr: minusMismatch,
+			wantErr: &TypeMismatchError{
+				Expr:     minusMismatch,
+				Expected: "int or float literal",
+				Got:      expression.NewStringLiteral("a"),
+			},
+		},
+		{
+			name:      "type mismatch in",
+			inputExpr: inMismatch,
+			wantErr: &TypeMismatchError{
+				Expr:     inMismatch,
+				Expected: "array expression",
+				Got:      expression.NewIntLiteral(1),
+			},
+		},
+		{
+			name:      "invalid index",
+			inputExpr: indexMismatch,
+			inputArgs: map[string]interface{}{
+				"x": []int{1},
+			},
+			wantErr: &InvalidIndexError{
+				Expr:  indexMismatch,
+				Path:  `$x["a"]`,
+				Index: expression.NewStringLiteral("a"),
+				Type:  "[]int",
+			},
+		},
+		{
+			name:      "index out of range",
+			inputExpr: indexOutOfRange,
+			inputArgs: map[string]interface{}{
+				"x": []int{1},
+			},
+			wantErr: &IndexOutOfRangeError{
+				Expr:   indexOutOfRange,
+				Path:   "$x[1]",
+				Index:  1,
+				Length: 1,
+			},
+		},
+		{
+			name:      "missing key",
+			inputExpr: indexMismatch,
+			inputArgs: map[string]interface{}{
+				"x": map[string]int{},
+			},
+			wantErr: &MissingKeyError{
+				Expr: indexMismatch,
+				Path: `$x["a"]`,
+				Key:  expression.NewStringLiteral("a"),
+			},
+		},
+		{
+			name:      "invalid access index",
+			inputExpr: indexOutOfRange,
+			inputArgs: map[string]interface{}{
+				"x": "a",
+			},
+			wantErr: &InvalidAccessError{
+				Expr: indexOutOfRange,
+				Path: "$x[1]",
+				Type: "string",
+			},
+		},
+		{
+			name:      "unknown function",
+			inputExpr: unknownFunction,
+			wantErr: &UnknownFunctionError{
+				Expr: unknownFunction,
+				Name: "unknown",
+			},
+		},
+		{
+			name:      "missing field",
+			inputExpr: memberMissing,
+			inputArgs: map[string]interface{}{
+				"x": map[string]interface{}{
+					"a": map[string]interface{}{},
+				},
+			},
+			wantErr: &MissingFieldError{
+				Expr: memberMissing,
+				Path: "$x.a.in",
+			},
+		},
+		{
+			name:      "invalid access field",
+			inputExpr: memberInvalid,
+			inputArgs: map[string]interface{}{
+				"x": 1,
+			},
+			wantErr: &InvalidAccessError{
+				Expr: memberInvalid,
+				Path: "$x.a",
+				Type: "int",
+			},
+		},
+		{
+			name:      "invalid access field of nil",
+			inputExpr: memberInvalid,
+			inputArgs: map[string]interface{}{
+				"x": nil,
+			},
+			wantErr: &InvalidAccessError{
+				Expr: memberInvalid,
+				Path: "$x.a",
+				Type: "nil",
+			},
+		},
+		{
+			name:      "unsupported operator",
+			inputExpr: unsupported,
+			wantErr: &UnsupportedOperatorError{
+				Expr:     unsupported,
+				Operator: token.Plus,
+			},
+		},
+		{
+			name:      "unsupported binary operator",
+			inputExpr: unsupportedBinary,
+			wantErr: &UnsupportedOperatorError{
+				Expr:     unsupportedBinary,
+				Operator: token.Not,
+			},
+		},
+		{
+			name:      "division by zero",
+			inputExpr: divZero,
+			wantErr: &DivisionByZeroError{
+				Expr:  divZero,
+				Left:  expression.NewIntLiteral(1),
+				Right: expression.NewIntLiteral(0),
+			},
+		},
+		{
+			name:      "modulo by zero",
+			inputExpr: modZero,
+			wantErr: &DivisionByZeroError{
+				Expr:  modZero,
+				Left:  expression.NewIntLiteral(1),
+				Right: expression.NewFloatLiteral(0),
+			},
+		},
+		{
+			name:      "int overflow",
+			inputExpr: overflow,
+			wantErr: &OverflowError{
+				Expr:     overflow,
+				Operator: token.Multiply,
+				Operands: []expression.Expression{
+					expression.NewIntLiteral(math.MaxInt64),
+					expression.NewIntLiteral(2),
+				},
+			},
+		},
+		{
+			name:      "int overflow negate",
+			inputExpr: negateOverflow,
+			inputArgs: map[string]interface{}{
+				"x": int64(math.MinInt64),
+			},
+			wantErr: &OverflowError{
+				Expr:     negateOverflow,
+				Operator: token.Minus,
+				Operands: []expression.Expression{
+					expression.NewIntLiteral(math.MinInt64),
+				},
+			},
+		},
+	}
+}
+
+func TestEvaluateVisitorVisitError(t *testing.T) {
+	for _, tc := range generateTestCaseError() {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewVisitor(tc.inputArgs)
+
+			_, gotErr := v.Visit(tc.inputExpr)
+			assert.Equal(t, tc.wantErr, gotErr)
+		})
+	}
+}
+
+func TestErrorAs(t *testing.T) {
+	varX := expression.NewVarExpression("x")
+
+	v := NewVisitor(nil)
+	_, err := v.Visit(varX)
+
+	// caller usually wrap error
+	err = fmt.Errorf("failed to evaluate: %w", err)
+
+	var missingErr *MissingVariableError
+	assert.True(t, errors.As(err, &missingErr))
+	assert.Equal(t, "x", missingErr.Name)
+	assert.Same(t, varX, missingErr.Expr)
+
+	var typeErr *TypeMismatchError
+	assert.False(t, errors.As(err, &typeErr))
+}
+
+func TestErrorAsMissingField(t *testing.T) {
+	v := NewVisitor(map[string]interface{}{
+		"user": map[string]interface{}{
+			"profile": map[string]interface{}{},
+		},
+	})
+	_, err := v.Visit(expression.NewMemberExpression(
+		expression.NewMemberExpression(expression.NewVarExpression("user"), "profile"),
+		"country",
+	))
+
+	err = fmt.Errorf("failed to evaluate: %w", err)
+
+	var fieldErr *MissingFieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "$user.profile.country", fieldErr.Path)
+	assert.Equal(t, "country", fieldErr.Expr.Field)
+}
@@ -0,0 +1,43 @@
+package evaluate
+
+import (
+	"github.com/haunt98/evaluator/expression"
+)
+
+// SharedTestCase is test case of visitor which is shared with other evaluation
+// so they can be compared with visitor, including typed error
+type SharedTestCase struct {
+	Name     string
+	Expr     expression.Expression
+	Args     map[string]interface{}
+	Registry *Registry
+}
+
+func SharedTestCases() []SharedTestCase {
+	var tests []testCase
+	tests = append(tests, generateTestCaseLiteral()...)
+	tests = append(tests, generateTestCaseVar()...)
+	tests = append(tests, generateTestCaseVarSlice()...)
+	tests = append(tests, generateTestCaseArray()...)
+	tests = append(tests, generateTestCaseFloat()...)
+	tests = append(tests, generateTestCaseArithmetic()...)
+	tests = append(tests, generateTestCaseCall()...)
+	tests = append(tests, generateTestCaseMember()...)
+	tests = append(tests, generateTestCaseIndex()...)
+	tests = append(tests, generateTestCaseUnary()...)
+	tests = append(tests, generateTestCaseBinary()...)
+	tests = append(tests, generateTestCaseNull()...)
+	tests = append(tests, generateTestCaseError()...)
+
+	sharedTests := make([]SharedTestCase, len(tests))
+	for i, tc := range tests {
+		sharedTests[i] = SharedTestCase{
+			Name:     tc.name,
+			Expr:     tc.inputExpr,
+			Args:     tc.inputArgs,
+			Registry: NewVisitor(nil, tc.inputOpts...).registry,
+		}
+	}
+
+	return sharedTests
+}
@@ -0,0 +1,196 @@
+package evaluate
+
+import (
+	"fmt"
+
+	"github.com/haunt98/evaluator/expression"
+)
+
+// Kind is kind of function param and result
+type Kind int
+
+const (
+	AnyKind Kind = iota
+	BoolKind
+	IntKind
+	FloatKind
+	StringKind
+	ArrayKind
+)
+
+var kindRepresents = map[Kind]string{
+	AnyKind:    "any",
+	BoolKind:   "bool",
+	IntKind:    "int",
+	FloatKind:  "float",
+	StringKind: "string",
+	ArrayKind:  "array",
+}
+
+func (k Kind) String() string {
+	represent, ok := kindRepresents[k]
+	if !ok {
+		return "unknown"
+	}
+
+	return represent
+}
+
+// KindOf return kind of go value, see Value for detail
+// Go value which is not returned by Value is AnyKind
+func KindOf(value interface{}) Kind {
+	switch value.(type) {
+	case bool:
+		return BoolKind
+	case int64:
+		return IntKind
+	case float64:
+		return FloatKind
+	case string:
+		return StringKind
+	case []interface{}:
+		return ArrayKind
+	default:
+		return AnyKind
+	}
+}
+
+// kindOfLiteral return kind of evaluated expression, same as KindOf of its Value
+func kindOfLiteral(expr expression.Expression) Kind {
+	switch expr.(type) {
+	case *expression.BoolLiteral:
+		return BoolKind
+	case *expression.IntLiteral:
+		return IntKind
+	case *expression.FloatLiteral:
+		return FloatKind
+	case *expression.StringLiteral:
+		return StringKind
+	case *expression.ArrayExpression:
+		return ArrayKind
+	default:
+		return AnyKind
+	}
+}
+
+// Function is go function which can be called inside expression
+// Params is kind of each arg, if Variadic is true the last param can be repeated
+// Optional is number of last params which can be missing, it can not be used with Variadic
+// Fn receives args as go value, see Value for detail
+// int arg is converted to float64 if param is FloatKind
+type Function struct {
+	Name     string
+	Params   []Kind
+	Variadic bool
+	Optional int
+	Result   Kind
+	Fn       func(args ...interface{}) (interface{}, error)
+}
+
+// CheckArity return error if number of args does not match params
+func (fn Function) CheckArity(n int) error {
+	switch {
+	case fn.Variadic:
+		if n < len(fn.Params)-1 {
+			return fmt.Errorf("function %s expect at least %d args got %d", fn.Name, len(fn.Params)-1, n)
+		}
+	case fn.Optional > 0:
+		if n < len(fn.Params)-fn.Optional || n > len(fn.Params) {
+			return fmt.Errorf("function %s expect %d to %d args got %d", fn.Name, len(fn.Params)-fn.Optional, len(fn.Params), n)
+		}
+	default:
+		if n != len(fn.Params) {
+			return fmt.Errorf("function %s expect %d args got %d", fn.Name, len(fn.Params), n)
+		}
+	}
+
+	return nil
+}
+
+// CheckArgs return error if args do not match params
+// int arg is converted to float64 in place if param is FloatKind
+func (fn Function) CheckArgs(args []interface{}) error {
+	if err := fn.CheckArity(len(args)); err != nil {
+		return err
+	}
+
+	for i, arg := range args {
+		param := fn.param(i)
+		if param == AnyKind {
+			continue
+		}
+
+		kind := KindOf(arg)
+		if param == FloatKind && kind == IntKind {
+			args[i] = float64(arg.(int64))
+			continue
+		}
+
+		if param != kind {
+			return fmt.Errorf("function %s arg %d expect %s got %s", fn.Name, i, param, kind)
+		}
+	}
+
+	return nil
+}
+
+func (fn Function) param(i int) Kind {
+	if fn.Variadic && i >= len(fn.Params)-1 {
+		return fn.Params[len(fn.Params)-1]
+	}
+
+	return fn.Params[i]
+}
+
+// Registry stores functions by name
+// Register is not safe for concurrent use, register all functions before evaluate
+type Registry struct {
+	fns map[string]Function
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		fns: make(map[string]Function),
+	}
+}
+
+func (r *Registry) Register(fns ...Function) error {
+	for _, fn := range fns {
+		if fn.Name == "" {
+			return fmt.Errorf("function name is empty")
+		}
+
+		if fn.Fn == nil {
+			return fmt.Errorf("function %s is nil", fn.Name)
+		}
+
+		if fn.Variadic && len(fn.Params) == 0 {
+			return fmt.Errorf("function %s is variadic without params", fn.Name)
+		}
+
+		if fn.Optional < 0 || fn.Optional > len(fn.Params) {
+			return fmt.Errorf("function %s has %d optional params out of %d params", fn.Name, fn.Optional, len(fn.Params))
+		}
+
+		if fn.Variadic && fn.Optional > 0 {
+			return fmt.Errorf("function %s is variadic with optional params", fn.Name)
+		}
+
+		if _, ok := r.fns[fn.Name]; ok {
+			return fmt.Errorf("function %s is already registered", fn.Name)
+		}
+
+		r.fns[fn.Name] = fn
+	}
+
+	return nil
+}
+
+func (r *Registry) Lookup(name string) (Function, bool) {
+	if r == nil {
+		return Function{}, false
+	}
+
+	fn, ok := r.fns[name]
+	return fn, ok
+}
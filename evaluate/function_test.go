@@ -0,0 +1,179 @@
+package evaluate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryRegister(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		return nil, nil
+	}
+
+	tests := []struct {
+		name    string
+		fns     []Function
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			fns: []Function{
+				{
+					Name: "a",
+					Fn:   fn,
+				},
+				{
+					Name:     "b",
+					Params:   []Kind{AnyKind},
+					Variadic: true,
+					Fn:       fn,
+				},
+			},
+		},
+		{
+			name: "empty name",
+			fns: []Function{
+				{
+					Fn: fn,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nil fn",
+			fns: []Function{
+				{
+					Name: "a",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "variadic without params",
+			fns: []Function{
+				{
+					Name:     "a",
+					Variadic: true,
+					Fn:       fn,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "optional more than params",
+			fns: []Function{
+				{
+					Name:     "a",
+					Params:   []Kind{AnyKind},
+					Optional: 2,
+					Fn:       fn,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "variadic with optional",
+			fns: []Function{
+				{
+					Name:     "a",
+					Params:   []Kind{AnyKind, AnyKind},
+					Variadic: true,
+					Optional: 1,
+					Fn:       fn,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate",
+			fns: []Function{
+				{
+					Name: "a",
+					Fn:   fn,
+				},
+				{
+					Name: "a",
+					Fn:   fn,
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotErr := NewRegistry().Register(tc.fns...)
+			if tc.wantErr {
+				assert.Error(t, gotErr)
+				return
+			}
+			assert.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestFunctionCheckArity(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      Function
+		n       int
+		wantErr string
+	}{
+		{
+			name: "exact",
+			fn:   Function{Name: "f", Params: []Kind{IntKind}},
+			n:    1,
+		},
+		{
+			name:    "exact wrong",
+			fn:      Function{Name: "f", Params: []Kind{IntKind}},
+			n:       2,
+			wantErr: "function f expect 1 args got 2",
+		},
+		{
+			name: "variadic",
+			fn:   Function{Name: "f", Params: []Kind{IntKind, IntKind}, Variadic: true},
+			n:    5,
+		},
+		{
+			name:    "variadic too few",
+			fn:      Function{Name: "f", Params: []Kind{IntKind, IntKind}, Variadic: true},
+			n:       0,
+			wantErr: "function f expect at least 1 args got 0",
+		},
+		{
+			name: "optional missing",
+			fn:   Function{Name: "f", Params: []Kind{IntKind, IntKind}, Optional: 1},
+			n:    1,
+		},
+		{
+			name: "optional present",
+			fn:   Function{Name: "f", Params: []Kind{IntKind, IntKind}, Optional: 1},
+			n:    2,
+		},
+		{
+			name:    "optional too many",
+			fn:      Function{Name: "f", Params: []Kind{IntKind, IntKind}, Optional: 1},
+			n:       3,
+			wantErr: "function f expect 1 to 2 args got 3",
+		},
+		{
+			name:    "optional too few",
+			fn:      Function{Name: "f", Params: []Kind{IntKind, IntKind}, Optional: 1},
+			n:       0,
+			wantErr: "function f expect 1 to 2 args got 0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotErr := tc.fn.CheckArity(tc.n)
+			if tc.wantErr != "" {
+				assert.EqualError(t, gotErr, tc.wantErr)
+				return
+			}
+			assert.NoError(t, gotErr)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package evaluate
+
+import (
+	"github.com/haunt98/evaluator/expression"
+)
+
+func (v *visitor) VisitIndex(expr *expression.IndexExpression) (expression.Expression, error) {
+	value, err := v.resolve(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return literal(value)
+}
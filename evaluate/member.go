@@ -0,0 +1,58 @@
+package evaluate
+
+import (
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/internal/access"
+)
+
+func (v *visitor) VisitMember(expr *expression.MemberExpression) (expression.Expression, error) {
+	value, err := v.resolve(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return literal(value)
+}
+
+// resolve return go value of expression without wrap it to literal
+// so map, struct, slice can be accessed later
+func (v *visitor) resolve(expr expression.Expression) (interface{}, error) {
+	switch e := expr.(type) {
+	case *expression.VarExpression:
+		value, ok := v.args[e.Value]
+		if !ok {
+			return nil, &MissingVariableError{
+				Expr: e,
+				Name: e.Value,
+			}
+		}
+
+		return value, nil
+	case *expression.MemberExpression:
+		object, err := v.resolve(e.Object)
+		if err != nil {
+			return nil, err
+		}
+
+		return access.Member(e, object)
+	case *expression.IndexExpression:
+		object, err := v.resolve(e.Object)
+		if err != nil {
+			return nil, err
+		}
+
+		indexResult, err := v.Visit(e.Index)
+		if err != nil {
+			return nil, err
+		}
+
+		return access.Index(e, object, indexResult)
+	default:
+		result, err := v.Visit(expr)
+		if err != nil {
+			return nil, err
+		}
+
+		return Value(result)
+	}
+}
@@ -0,0 +1,44 @@
+package evaluate
+
+import (
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/internal/number"
+	"github.com/haunt98/evaluator/token"
+)
+
+// compareNumber compare left and right using op
+// int with int is compared as int64
+// int with float is compared as float64
+// expr is only used to return error
+func compareNumber(op token.Token, expr expression.Expression, left, right expression.Expression) (bool, error) {
+	switch l := left.(type) {
+	case *expression.IntLiteral:
+		switch r := right.(type) {
+		case *expression.IntLiteral:
+			return number.CompareInt(op, l.Value, r.Value)
+		case *expression.FloatLiteral:
+			return number.CompareFloat(op, float64(l.Value), r.Value)
+		default:
+			return false, newNumberMismatchError(expr, right)
+		}
+	case *expression.FloatLiteral:
+		switch r := right.(type) {
+		case *expression.IntLiteral:
+			return number.CompareFloat(op, l.Value, float64(r.Value))
+		case *expression.FloatLiteral:
+			return number.CompareFloat(op, l.Value, r.Value)
+		default:
+			return false, newNumberMismatchError(expr, right)
+		}
+	default:
+		return false, newNumberMismatchError(expr, left)
+	}
+}
+
+func newNumberMismatchError(expr, got expression.Expression) *TypeMismatchError {
+	return &TypeMismatchError{
+		Expr:     expr,
+		Expected: "int or float literal",
+		Got:      got,
+	}
+}
@@ -1,9 +1,8 @@
 package evaluate
 
 import (
-	"fmt"
-
 	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/internal/number"
 )
 
 func (v *visitor) visitNot(expr *expression.UnaryExpression) (expression.Expression, error) {
@@ -14,8 +13,37 @@ func (v *visitor) visitNot(expr *expression.UnaryExpression) (expression.Express
 
 	childLit, ok := child.(*expression.BoolLiteral)
 	if !ok {
-		return nil, fmt.Errorf("expect bool")
+		return nil, &TypeMismatchError{
+			Expr:     expr,
+			Expected: "bool literal",
+			Got:      child,
+		}
 	}
 
 	return expression.NewBoolLiteral(!childLit.Value), nil
 }
+
+func (v *visitor) visitMinus(expr *expression.UnaryExpression) (expression.Expression, error) {
+	child, err := v.Visit(expr.Child)
+	if err != nil {
+		return nil, err
+	}
+
+	switch childLit := child.(type) {
+	case *expression.IntLiteral:
+		result, err := number.Negate(childLit.Value)
+		if err != nil {
+			return nil, &OverflowError{
+				Expr:     expr,
+				Operator: expr.Operator,
+				Operands: []expression.Expression{child},
+			}
+		}
+
+		return expression.NewIntLiteral(result), nil
+	case *expression.FloatLiteral:
+		return expression.NewFloatLiteral(-childLit.Value), nil
+	default:
+		return nil, newNumberMismatchError(expr, child)
+	}
+}
@@ -0,0 +1,124 @@
+package evaluate
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/haunt98/evaluator/expression"
+)
+
+// Value unwrap evaluated expression to go value
+// bool literal -> bool
+// int literal -> int64
+// float literal -> float64
+// string literal -> string
+// null literal -> nil
+// array expression -> []interface{}
+func Value(expr expression.Expression) (interface{}, error) {
+	switch e := expr.(type) {
+	case *expression.NullLiteral:
+		return nil, nil
+	case *expression.BoolLiteral:
+		return e.Value, nil
+	case *expression.IntLiteral:
+		return e.Value, nil
+	case *expression.FloatLiteral:
+		return e.Value, nil
+	case *expression.StringLiteral:
+		return e.Value, nil
+	case *expression.ArrayExpression:
+		values := make([]interface{}, len(e.Children))
+		for i, child := range e.Children {
+			value, err := Value(child)
+			if err != nil {
+				return nil, err
+			}
+
+			values[i] = value
+		}
+
+		return values, nil
+	case *expression.SetLiteral:
+		return Value(expression.NewArrayExpression(e.Children...))
+	default:
+		return nil, fmt.Errorf("not implement value of %T", e)
+	}
+}
+
+// literal wrap go value to literal expression
+// slice and array are converted to array expression recursively
+// nil, nil pointer are converted to null literal
+func literal(value interface{}) (expression.Expression, error) {
+	switch v := value.(type) {
+	case nil:
+		return expression.NewNullLiteral(), nil
+	case bool:
+		return expression.NewBoolLiteral(v), nil
+	case int:
+		return expression.NewIntLiteral(int64(v)), nil
+	case int64:
+		return expression.NewIntLiteral(v), nil
+	case float32:
+		return expression.NewFloatLiteral(float64(v)), nil
+	case float64:
+		return expression.NewFloatLiteral(v), nil
+	case string:
+		return expression.NewStringLiteral(v), nil
+	case []interface{}:
+		children := make([]expression.Expression, len(v))
+		for i, childValue := range v {
+			child, err := literal(childValue)
+			if err != nil {
+				return nil, err
+			}
+
+			children[i] = child
+		}
+
+		return expression.NewArrayExpression(children...), nil
+	default:
+		return reflectLiteral(reflect.ValueOf(value))
+	}
+}
+
+// reflectLiteral handle types which are not handled by literal
+// such as []string, []int, [2]int, int32, uint, type Role string, *string, ...
+func reflectLiteral(rv reflect.Value) (expression.Expression, error) {
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return expression.NewNullLiteral(), nil
+		}
+
+		return literal(rv.Elem().Interface())
+	case reflect.Bool:
+		return expression.NewBoolLiteral(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return expression.NewIntLiteral(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("int overflow %d", rv.Uint())
+		}
+
+		return expression.NewIntLiteral(int64(rv.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return expression.NewFloatLiteral(rv.Float()), nil
+	case reflect.String:
+		return expression.NewStringLiteral(rv.String()), nil
+	case reflect.Slice, reflect.Array:
+		children := make([]expression.Expression, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			child, err := literal(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+
+			children[i] = child
+		}
+
+		return expression.NewArrayExpression(children...), nil
+	default:
+		return nil, fmt.Errorf("not implement var type %s", rv.Type())
+	}
+}
@@ -1,8 +1,6 @@
 package evaluate
 
 import (
-	"fmt"
-
 	"github.com/haunt98/evaluator/expression"
 	"github.com/haunt98/evaluator/token"
 )
@@ -10,13 +8,29 @@ import (
 var _ expression.Visitor = (*visitor)(nil)
 
 type visitor struct {
-	args map[string]interface{}
+	args     map[string]interface{}
+	registry *Registry
 }
 
-func NewVisitor(args map[string]interface{}) *visitor {
-	return &visitor{
+type Option func(v *visitor)
+
+// WithRegistry allow expression to call functions in registry
+func WithRegistry(registry *Registry) Option {
+	return func(v *visitor) {
+		v.registry = registry
+	}
+}
+
+func NewVisitor(args map[string]interface{}, opts ...Option) *visitor {
+	v := &visitor{
 		args: args,
 	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
 }
 
 func (v *visitor) Visit(expr expression.Expression) (expression.Expression, error) {
@@ -30,30 +44,40 @@ func (v *visitor) VisitLiteral(expr expression.Expression) (expression.Expressio
 func (v *visitor) VisitVar(expr *expression.VarExpression) (expression.Expression, error) {
 	value, ok := v.args[expr.Value]
 	if !ok {
-		return nil, fmt.Errorf("args missing %s", expr.Value)
+		return nil, &MissingVariableError{
+			Expr: expr,
+			Name: expr.Value,
+		}
 	}
 
-	// TODO: add more types
-	switch v := value.(type) {
-	case bool:
-		return expression.NewBoolLiteral(v), nil
-	case int:
-		return expression.NewIntLiteral(int64(v)), nil
-	case int64:
-		return expression.NewIntLiteral(v), nil
-	case string:
-		return expression.NewStringLiteral(v), nil
-	default:
-		return nil, fmt.Errorf("not implement var type %T", v)
+	return literal(value)
+}
+
+func (v *visitor) VisitArray(expr *expression.ArrayExpression) (expression.Expression, error) {
+	children := make([]expression.Expression, len(expr.Children))
+	for i, child := range expr.Children {
+		result, err := v.Visit(child)
+		if err != nil {
+			return nil, err
+		}
+
+		children[i] = result
 	}
+
+	return expression.NewArrayExpression(children...), nil
 }
 
 func (v *visitor) VisitUnary(expr *expression.UnaryExpression) (expression.Expression, error) {
 	switch expr.Operator {
 	case token.Not:
 		return v.visitNot(expr)
+	case token.Minus:
+		return v.visitMinus(expr)
 	default:
-		return nil, fmt.Errorf("not implement visit unary operator %s", expr.Operator)
+		return nil, &UnsupportedOperatorError{
+			Expr:     expr,
+			Operator: expr.Operator,
+		}
 	}
 }
 
@@ -68,19 +92,18 @@ func (v *visitor) VisitBinary(expr *expression.BinaryExpression) (expression.Exp
 		return v.visitEqual(expr)
 	case token.NotEqual:
 		return v.visitNotEqual(expr)
-	case token.Less:
-		return v.visitLess(expr)
-	case token.LessOrEqual:
-		return v.visitLessOrEqual(expr)
-	case token.Greater:
-		return v.visitGreater(expr)
-	case token.GreaterOrEqual:
-		return v.visitGreaterOrEqual(expr)
+	case token.Less, token.LessOrEqual, token.Greater, token.GreaterOrEqual:
+		return v.visitCompare(expr)
 	case token.In:
 		return v.visitIn(expr)
 	case token.NotIn:
 		return v.visitNotIn(expr)
+	case token.Plus, token.Minus, token.Multiply, token.Divide, token.Modulo:
+		return v.visitArithmetic(expr)
 	default:
-		return nil, fmt.Errorf("not implement visit binary operator %s", expr.Operator)
+		return nil, &UnsupportedOperatorError{
+			Expr:     expr,
+			Operator: expr.Operator,
+		}
 	}
 }
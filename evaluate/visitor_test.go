@@ -1,6 +1,9 @@
 package evaluate
 
 import (
+	"fmt"
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/haunt98/evaluator/expression"
@@ -12,6 +15,7 @@ type testCase struct {
 	name       string
 	inputExpr  expression.Expression
 	inputArgs  map[string]interface{}
+	inputOpts  []Option
 	wantResult expression.Expression
 	wantErr    error
 }
@@ -73,13 +77,979 @@ func generateTestCaseVar() []testCase {
 			},
 			wantResult: expression.NewIntLiteral(1),
 		},
+		{
+			name:      "var float32",
+			inputExpr: expression.NewVarExpression("x"),
+			inputArgs: map[string]interface{}{
+				"x": float32(1.5),
+			},
+			wantResult: expression.NewFloatLiteral(1.5),
+		},
+		{
+			name:      "var float64",
+			inputExpr: expression.NewVarExpression("x"),
+			inputArgs: map[string]interface{}{
+				"x": 1.5,
+			},
+			wantResult: expression.NewFloatLiteral(1.5),
+		},
 		{
 			name:      "var string",
 			inputExpr: expression.NewVarExpression("x"),
 			inputArgs: map[string]interface{}{
 				"x": "xxx",
 			},
-			wantResult: expression.NewStringLiteral("xxx"),
+			wantResult: expression.NewStringLiteral("xxx"),
+		},
+	}
+}
+
+type role string
+
+func generateTestCaseVarSlice() []testCase {
+	return []testCase{
+		{
+			name:      "var slice string",
+			inputExpr: expression.NewVarExpression("x"),
+			inputArgs: map[string]interface{}{
+				"x": []string{"a", "b"},
+			},
+			wantResult: expression.NewArrayExpression(
+				expression.NewStringLiteral("a"),
+				expression.NewStringLiteral("b"),
+			),
+		},
+		{
+			name:      "var slice int",
+			inputExpr: expression.NewVarExpression("x"),
+			inputArgs: map[string]interface{}{
+				"x": []int{1, 2},
+			},
+			wantResult: expression.NewArrayExpression(
+				expression.NewIntLiteral(1),
+				expression.NewIntLiteral(2),
+			),
+		},
+		{
+			name:      "var slice empty",
+			inputExpr: expression.NewVarExpression("x"),
+			inputArgs: map[string]interface{}{
+				"x": []int(nil),
+			},
+			wantResult: expression.NewArrayExpression(),
+		},
+		{
+			name:      "var array uint8",
+			inputExpr: expression.NewVarExpression("x"),
+			inputArgs: map[string]interface{}{
+				"x": [2]uint8{1, 2},
+			},
+			wantResult: expression.NewArrayExpression(
+				expression.NewIntLiteral(1),
+				expression.NewIntLiteral(2),
+			),
+		},
+		{
+			name:      "var slice nested",
+			inputExpr: expression.NewVarExpression("x"),
+			inputArgs: map[string]interface{}{
+				"x": []interface{}{
+					1,
+					[]string{"a"},
+					[][]float32{{1.5}},
+				},
+			},
+			wantResult: expression.NewArrayExpression(
+				expression.NewIntLiteral(1),
+				expression.NewArrayExpression(
+					expression.NewStringLiteral("a"),
+				),
+				expression.NewArrayExpression(
+					expression.NewArrayExpression(
+						expression.NewFloatLiteral(1.5),
+					),
+				),
+			),
+		},
+		{
+			name:      "var slice custom type",
+			inputExpr: expression.NewVarExpression("x"),
+			inputArgs: map[string]interface{}{
+				"x": []role{"admin"},
+			},
+			wantResult: expression.NewArrayExpression(
+				expression.NewStringLiteral("admin"),
+			),
+		},
+		{
+			name:      "var slice unsupported item",
+			inputExpr: expression.NewVarExpression("x"),
+			inputArgs: map[string]interface{}{
+				"x": []map[string]int{{}},
+			},
+			wantErr: fmt.Errorf("not implement var type map[string]int"),
+		},
+		{
+			name:      "var uint overflow",
+			inputExpr: expression.NewVarExpression("x"),
+			inputArgs: map[string]interface{}{
+				"x": uint64(math.MaxUint64),
+			},
+			wantErr: fmt.Errorf("int overflow 18446744073709551615"),
+		},
+		{
+			name: "var in var slice",
+			inputExpr: expression.NewBinaryExpression(token.In,
+				expression.NewVarExpression("role"),
+				expression.NewVarExpression("allowedRoles"),
+			),
+			inputArgs: map[string]interface{}{
+				"role":         "admin",
+				"allowedRoles": []string{"admin", "editor"},
+			},
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name: "string in var slice",
+			inputExpr: expression.NewBinaryExpression(token.In,
+				expression.NewStringLiteral("admin"),
+				expression.NewVarExpression("roles"),
+			),
+			inputArgs: map[string]interface{}{
+				"roles": []role{"editor"},
+			},
+			wantResult: expression.NewBoolLiteral(false),
+		},
+		{
+			name: "string not in var slice",
+			inputExpr: expression.NewBinaryExpression(token.NotIn,
+				expression.NewStringLiteral("admin"),
+				expression.NewVarExpression("roles"),
+			),
+			inputArgs: map[string]interface{}{
+				"roles": []role{"editor"},
+			},
+			wantResult: expression.NewBoolLiteral(true),
+		},
+	}
+}
+
+func generateTestCaseArray() []testCase {
+	return []testCase{
+		{
+			name: "array with var",
+			inputExpr: expression.NewArrayExpression(
+				expression.NewVarExpression("x"),
+				expression.NewIntLiteral(1),
+			),
+			inputArgs: map[string]interface{}{
+				"x": "a",
+			},
+			wantResult: expression.NewArrayExpression(
+				expression.NewStringLiteral("a"),
+				expression.NewIntLiteral(1),
+			),
+		},
+		{
+			name: "array with binary",
+			inputExpr: expression.NewArrayExpression(
+				expression.NewBinaryExpression(token.Equal,
+					expression.NewIntLiteral(1),
+					expression.NewIntLiteral(1),
+				),
+			),
+			wantResult: expression.NewArrayExpression(
+				expression.NewBoolLiteral(true),
+			),
+		},
+		{
+			name: "array nested",
+			inputExpr: expression.NewArrayExpression(
+				expression.NewArrayExpression(
+					expression.NewVarExpression("x"),
+				),
+			),
+			inputArgs: map[string]interface{}{
+				"x": 1,
+			},
+			wantResult: expression.NewArrayExpression(
+				expression.NewArrayExpression(
+					expression.NewIntLiteral(1),
+				),
+			),
+		},
+		{
+			name: "array args missing",
+			inputExpr: expression.NewArrayExpression(
+				expression.NewVarExpression("x"),
+			),
+			wantErr: fmt.Errorf("args missing x"),
+		},
+		{
+			name: "in array with var",
+			inputExpr: expression.NewBinaryExpression(token.In,
+				expression.NewVarExpression("x"),
+				expression.NewArrayExpression(
+					expression.NewVarExpression("a"),
+					expression.NewVarExpression("b"),
+				),
+			),
+			inputArgs: map[string]interface{}{
+				"x": 2,
+				"a": 1,
+				"b": 2,
+			},
+			wantResult: expression.NewBoolLiteral(true),
+		},
+	}
+}
+
+func generateTestCaseFloat() []testCase {
+	return []testCase{
+		{
+			name:       "float",
+			inputExpr:  expression.NewFloatLiteral(1.5),
+			wantResult: expression.NewFloatLiteral(1.5),
+		},
+		{
+			name: "equal float",
+			inputExpr: expression.NewBinaryExpression(token.Equal,
+				expression.NewFloatLiteral(1.5),
+				expression.NewFloatLiteral(1.5),
+			),
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name: "equal int float",
+			inputExpr: expression.NewBinaryExpression(token.Equal,
+				expression.NewIntLiteral(1),
+				expression.NewFloatLiteral(1),
+			),
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name: "equal float int",
+			inputExpr: expression.NewBinaryExpression(token.Equal,
+				expression.NewFloatLiteral(1.5),
+				expression.NewIntLiteral(1),
+			),
+			wantResult: expression.NewBoolLiteral(false),
+		},
+		{
+			name: "less float",
+			inputExpr: expression.NewBinaryExpression(token.Less,
+				expression.NewFloatLiteral(0.5),
+				expression.NewFloatLiteral(0.75),
+			),
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name: "less or equal int float",
+			inputExpr: expression.NewBinaryExpression(token.LessOrEqual,
+				expression.NewIntLiteral(1),
+				expression.NewFloatLiteral(0.75),
+			),
+			wantResult: expression.NewBoolLiteral(false),
+		},
+		{
+			name: "greater float int",
+			inputExpr: expression.NewBinaryExpression(token.Greater,
+				expression.NewFloatLiteral(1.5),
+				expression.NewIntLiteral(1),
+			),
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name: "greater or equal var float",
+			inputExpr: expression.NewBinaryExpression(token.GreaterOrEqual,
+				expression.NewVarExpression("score"),
+				expression.NewFloatLiteral(0.75),
+			),
+			inputArgs: map[string]interface{}{
+				"score": 0.8,
+			},
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name: "in float",
+			inputExpr: expression.NewBinaryExpression(token.In,
+				expression.NewIntLiteral(2),
+				expression.NewArrayExpression(
+					expression.NewFloatLiteral(1.5),
+					expression.NewFloatLiteral(2),
+				),
+			),
+			wantResult: expression.NewBoolLiteral(true),
+		},
+	}
+}
+
+func generateTestCaseArithmetic() []testCase {
+	return []testCase{
+		{
+			name: "plus int",
+			inputExpr: expression.NewBinaryExpression(token.Plus,
+				expression.NewIntLiteral(1),
+				expression.NewIntLiteral(2),
+			),
+			wantResult: expression.NewIntLiteral(3),
+		},
+		{
+			name: "minus int",
+			inputExpr: expression.NewBinaryExpression(token.Minus,
+				expression.NewIntLiteral(1),
+				expression.NewIntLiteral(2),
+			),
+			wantResult: expression.NewIntLiteral(-1),
+		},
+		{
+			name: "multiply int",
+			inputExpr: expression.NewBinaryExpression(token.Multiply,
+				expression.NewIntLiteral(3),
+				expression.NewIntLiteral(-2),
+			),
+			wantResult: expression.NewIntLiteral(-6),
+		},
+		{
+			name: "divide int",
+			inputExpr: expression.NewBinaryExpression(token.Divide,
+				expression.NewIntLiteral(7),
+				expression.NewIntLiteral(2),
+			),
+			wantResult: expression.NewIntLiteral(3),
+		},
+		{
+			name: "modulo int",
+			inputExpr: expression.NewBinaryExpression(token.Modulo,
+				expression.NewIntLiteral(7),
+				expression.NewIntLiteral(3),
+			),
+			wantResult: expression.NewIntLiteral(1),
+		},
+		{
+			name: "plus int float",
+			inputExpr: expression.NewBinaryExpression(token.Plus,
+				expression.NewIntLiteral(1),
+				expression.NewFloatLiteral(0.5),
+			),
+			wantResult: expression.NewFloatLiteral(1.5),
+		},
+		{
+			name: "multiply float int",
+			inputExpr: expression.NewBinaryExpression(token.Multiply,
+				expression.NewFloatLiteral(1.5),
+				expression.NewIntLiteral(2),
+			),
+			wantResult: expression.NewFloatLiteral(3),
+		},
+		{
+			name: "divide float",
+			inputExpr: expression.NewBinaryExpression(token.Divide,
+				expression.NewFloatLiteral(1),
+				expression.NewFloatLiteral(4),
+			),
+			wantResult: expression.NewFloatLiteral(0.25),
+		},
+		{
+			name: "modulo float",
+			inputExpr: expression.NewBinaryExpression(token.Modulo,
+				expression.NewFloatLiteral(7.5),
+				expression.NewIntLiteral(2),
+			),
+			wantResult: expression.NewFloatLiteral(1.5),
+		},
+		{
+			name: "multiply var",
+			inputExpr: expression.NewBinaryExpression(token.Multiply,
+				expression.NewVarExpression("price"),
+				expression.NewVarExpression("qty"),
+			),
+			inputArgs: map[string]interface{}{
+				"price": 10,
+				"qty":   5,
+			},
+			wantResult: expression.NewIntLiteral(50),
+		},
+		{
+			name: "divide int by zero",
+			inputExpr: expression.NewBinaryExpression(token.Divide,
+				expression.NewIntLiteral(1),
+				expression.NewIntLiteral(0),
+			),
+			wantErr: fmt.Errorf("division by zero 1 / 0"),
+		},
+		{
+			name: "modulo int by zero",
+			inputExpr: expression.NewBinaryExpression(token.Modulo,
+				expression.NewIntLiteral(1),
+				expression.NewIntLiteral(0),
+			),
+			wantErr: fmt.Errorf("division by zero 1 %% 0"),
+		},
+		{
+			name: "divide float by zero",
+			inputExpr: expression.NewBinaryExpression(token.Divide,
+				expression.NewFloatLiteral(1.5),
+				expression.NewFloatLiteral(0),
+			),
+			wantErr: fmt.Errorf("division by zero 1.5 / 0"),
+		},
+		{
+			name: "plus int overflow",
+			inputExpr: expression.NewBinaryExpression(token.Plus,
+				expression.NewIntLiteral(math.MaxInt64),
+				expression.NewIntLiteral(1),
+			),
+			wantErr: fmt.Errorf("int overflow 9223372036854775807 + 1"),
+		},
+		{
+			name: "minus int overflow",
+			inputExpr: expression.NewBinaryExpression(token.Minus,
+				expression.NewIntLiteral(math.MinInt64),
+				expression.NewIntLiteral(1),
+			),
+			wantErr: fmt.Errorf("int overflow -9223372036854775808 - 1"),
+		},
+		{
+			name: "multiply int overflow",
+			inputExpr: expression.NewBinaryExpression(token.Multiply,
+				expression.NewIntLiteral(math.MaxInt64),
+				expression.NewIntLiteral(2),
+			),
+			wantErr: fmt.Errorf("int overflow 9223372036854775807 * 2"),
+		},
+		{
+			name: "divide int overflow",
+			inputExpr: expression.NewBinaryExpression(token.Divide,
+				expression.NewIntLiteral(math.MinInt64),
+				expression.NewIntLiteral(-1),
+			),
+			wantErr: fmt.Errorf("int overflow -9223372036854775808 / -1"),
+		},
+		{
+			name: "plus string",
+			inputExpr: expression.NewBinaryExpression(token.Plus,
+				expression.NewStringLiteral("a"),
+				expression.NewIntLiteral(1),
+			),
+			wantErr: fmt.Errorf("expect int or float literal got \"a\""),
+		},
+		{
+			name:       "minus int",
+			inputExpr:  expression.NewUnaryExpression(token.Minus, expression.NewIntLiteral(1)),
+			wantResult: expression.NewIntLiteral(-1),
+		},
+		{
+			name:       "minus float",
+			inputExpr:  expression.NewUnaryExpression(token.Minus, expression.NewFloatLiteral(1.5)),
+			wantResult: expression.NewFloatLiteral(-1.5),
+		},
+		{
+			name:      "minus int overflow",
+			inputExpr: expression.NewUnaryExpression(token.Minus, expression.NewIntLiteral(math.MinInt64)),
+			wantErr:   fmt.Errorf("int overflow negate -9223372036854775808"),
+		},
+	}
+}
+
+func generateTestCaseCall() []testCase {
+	registry := NewRegistry()
+	_ = registry.Register(
+		Function{
+			Name:   "double",
+			Params: []Kind{IntKind},
+			Result: IntKind,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return args[0].(int64) * 2, nil
+			},
+		},
+		Function{
+			Name:   "half",
+			Params: []Kind{FloatKind},
+			Result: FloatKind,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return args[0].(float64) / 2, nil
+			},
+		},
+		Function{
+			Name:     "sum",
+			Params:   []Kind{IntKind},
+			Variadic: true,
+			Result:   IntKind,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				var result int64
+				for _, arg := range args {
+					result += arg.(int64)
+				}
+				return result, nil
+			},
+		},
+		Function{
+			Name:   "fail",
+			Params: []Kind{},
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return nil, fmt.Errorf("always fail")
+			},
+		},
+		Function{
+			Name:   "length",
+			Params: []Kind{StringKind},
+			Result: IntKind,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return len(args[0].(string)), nil
+			},
+		},
+		Function{
+			Name:   "third",
+			Params: []Kind{FloatKind},
+			Result: FloatKind,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return float32(args[0].(float64) / 3), nil
+			},
+		},
+		Function{
+			Name:   "split",
+			Params: []Kind{StringKind},
+			Result: ArrayKind,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return strings.Split(args[0].(string), ","), nil
+			},
+		},
+		Function{
+			Name:   "wrong",
+			Params: []Kind{},
+			Result: BoolKind,
+			Fn: func(args ...interface{}) (interface{}, error) {
+				return 1, nil
+			},
+		},
+	)
+
+	return []testCase{
+		{
+			name:       "call",
+			inputExpr:  expression.NewCallExpression("double", expression.NewVarExpression("x")),
+			inputArgs:  map[string]interface{}{"x": 2},
+			inputOpts:  []Option{WithRegistry(registry)},
+			wantResult: expression.NewIntLiteral(4),
+		},
+		{
+			name:       "call int to float",
+			inputExpr:  expression.NewCallExpression("half", expression.NewIntLiteral(1)),
+			inputOpts:  []Option{WithRegistry(registry)},
+			wantResult: expression.NewFloatLiteral(0.5),
+		},
+		{
+			name: "call variadic",
+			inputExpr: expression.NewCallExpression("sum",
+				expression.NewIntLiteral(1),
+				expression.NewIntLiteral(2),
+				expression.NewIntLiteral(3),
+			),
+			inputOpts:  []Option{WithRegistry(registry)},
+			wantResult: expression.NewIntLiteral(6),
+		},
+		{
+			name:       "call variadic no args",
+			inputExpr:  expression.NewCallExpression("sum"),
+			inputOpts:  []Option{WithRegistry(registry)},
+			wantResult: expression.NewIntLiteral(0),
+		},
+		{
+			name:      "call unknown function",
+			inputExpr: expression.NewCallExpression("double", expression.NewIntLiteral(1)),
+			wantErr:   fmt.Errorf("not implement function double"),
+		},
+		{
+			name:      "call wrong number of args",
+			inputExpr: expression.NewCallExpression("double"),
+			inputOpts: []Option{WithRegistry(registry)},
+			wantErr:   fmt.Errorf("function double expect 1 args got 0"),
+		},
+		{
+			name:      "call wrong kind of arg",
+			inputExpr: expression.NewCallExpression("double", expression.NewStringLiteral("a")),
+			inputOpts: []Option{WithRegistry(registry)},
+			wantErr:   fmt.Errorf("function double arg 0 expect int got string"),
+		},
+		{
+			name:      "call fail",
+			inputExpr: expression.NewCallExpression("fail"),
+			inputOpts: []Option{WithRegistry(registry)},
+			wantErr:   fmt.Errorf("failed to call function fail: %w", fmt.Errorf("always fail")),
+		},
+		{
+			name:      "call wrong return",
+			inputExpr: expression.NewCallExpression("wrong"),
+			inputOpts: []Option{WithRegistry(registry)},
+			wantErr:   fmt.Errorf("function wrong expect return bool got 1"),
+		},
+		{
+			name:       "call return go int",
+			inputExpr:  expression.NewCallExpression("length", expression.NewStringLiteral("abc")),
+			inputOpts:  []Option{WithRegistry(registry)},
+			wantResult: expression.NewIntLiteral(3),
+		},
+		{
+			name:       "call return go float32",
+			inputExpr:  expression.NewCallExpression("third", expression.NewFloatLiteral(1.5)),
+			inputOpts:  []Option{WithRegistry(registry)},
+			wantResult: expression.NewFloatLiteral(0.5),
+		},
+		{
+			name:      "call return go []string",
+			inputExpr: expression.NewCallExpression("split", expression.NewStringLiteral("a,b")),
+			inputOpts: []Option{WithRegistry(registry)},
+			wantResult: expression.NewArrayExpression(
+				expression.NewStringLiteral("a"),
+				expression.NewStringLiteral("b"),
+			),
+		},
+	}
+}
+
+type profile struct {
+	Country string
+	Age     int    `evaluator:"age"`
+	Secret  string `evaluator:"-"`
+	private string
+}
+
+type user struct {
+	Name    string
+	Profile *profile `evaluator:"profile"`
+	Tags    map[string]interface{}
+}
+
+func generateTestCaseMember() []testCase {
+	args := map[string]interface{}{
+		"m": map[string]interface{}{
+			"a": map[string]interface{}{
+				"b": 1,
+			},
+			"s": "x",
+		},
+		"user": &user{
+			Name: "a",
+			Profile: &profile{
+				Country: "vn",
+				Age:     18,
+				Secret:  "secret",
+				private: "private",
+			},
+			Tags: map[string]interface{}{
+				"x": true,
+			},
+		},
+		"nil": (*user)(nil),
+	}
+
+	return []testCase{
+		{
+			name: "member map",
+			inputExpr: expression.NewMemberExpression(
+				expression.NewMemberExpression(expression.NewVarExpression("m"), "a"),
+				"b",
+			),
+			inputArgs:  args,
+			wantResult: expression.NewIntLiteral(1),
+		},
+		{
+			name:       "member struct",
+			inputExpr:  expression.NewMemberExpression(expression.NewVarExpression("user"), "Name"),
+			inputArgs:  args,
+			wantResult: expression.NewStringLiteral("a"),
+		},
+		{
+			name: "member struct tag",
+			inputExpr: expression.NewMemberExpression(
+				expression.NewMemberExpression(expression.NewVarExpression("user"), "profile"),
+				"age",
+			),
+			inputArgs:  args,
+			wantResult: expression.NewIntLiteral(18),
+		},
+		{
+			name: "member struct pointer",
+			inputExpr: expression.NewMemberExpression(
+				expression.NewMemberExpression(expression.NewVarExpression("user"), "profile"),
+				"Country",
+			),
+			inputArgs:  args,
+			wantResult: expression.NewStringLiteral("vn"),
+		},
+		{
+			name: "member struct map",
+			inputExpr: expression.NewMemberExpression(
+				expression.NewMemberExpression(expression.NewVarExpression("user"), "Tags"),
+				"x",
+			),
+			inputArgs:  args,
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name: "member in binary",
+			inputExpr: expression.NewBinaryExpression(token.Equal,
+				expression.NewMemberExpression(
+					expression.NewMemberExpression(expression.NewVarExpression("user"), "profile"),
+					"Country",
+				),
+				expression.NewStringLiteral("vn"),
+			),
+			inputArgs:  args,
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name:      "member args missing",
+			inputExpr: expression.NewMemberExpression(expression.NewVarExpression("x"), "a"),
+			wantErr:   fmt.Errorf("args missing x"),
+		},
+		{
+			name:      "member map missing",
+			inputExpr: expression.NewMemberExpression(expression.NewVarExpression("m"), "x"),
+			inputArgs: args,
+			wantErr:   fmt.Errorf("missing field $m.x"),
+		},
+		{
+			name: "member struct tag renamed",
+			inputExpr: expression.NewMemberExpression(
+				expression.NewMemberExpression(expression.NewVarExpression("user"), "profile"),
+				"Age",
+			),
+			inputArgs: args,
+			wantErr:   fmt.Errorf("missing field $user.profile.Age"),
+		},
+		{
+			name: "member struct tag ignored",
+			inputExpr: expression.NewMemberExpression(
+				expression.NewMemberExpression(expression.NewVarExpression("user"), "profile"),
+				"Secret",
+			),
+			inputArgs: args,
+			wantErr:   fmt.Errorf("missing field $user.profile.Secret"),
+		},
+		{
+			name: "member struct unexported",
+			inputExpr: expression.NewMemberExpression(
+				expression.NewMemberExpression(expression.NewVarExpression("user"), "profile"),
+				"private",
+			),
+			inputArgs: args,
+			wantErr:   fmt.Errorf("missing field $user.profile.private"),
+		},
+		{
+			name: "member wrong type",
+			inputExpr: expression.NewMemberExpression(
+				expression.NewMemberExpression(expression.NewVarExpression("m"), "s"),
+				"x",
+			),
+			inputArgs: args,
+			wantErr:   fmt.Errorf("can not access $m.s.x of string"),
+		},
+		{
+			name:      "member nil",
+			inputExpr: expression.NewMemberExpression(expression.NewVarExpression("nil"), "Name"),
+			inputArgs: args,
+			wantErr:   fmt.Errorf("can not access $nil.Name of nil"),
+		},
+		{
+			name: "member of array",
+			inputExpr: expression.NewMemberExpression(
+				expression.NewArrayExpression(expression.NewIntLiteral(1)),
+				"x",
+			),
+			wantErr: fmt.Errorf("can not access [1].x of []interface {}"),
+		},
+	}
+}
+
+func generateTestCaseIndex() []testCase {
+	args := map[string]interface{}{
+		"items": []string{"a", "b"},
+		"array": [2]int{1, 2},
+		"headers": map[string]string{
+			"x-id": "1",
+		},
+		"codes": map[int32]string{
+			1: "a",
+		},
+		"a": map[string]interface{}{
+			"b": []interface{}{
+				map[string]interface{}{
+					"c": true,
+				},
+			},
+		},
+		"users": []*user{
+			{
+				Name: "a",
+			},
+		},
+	}
+
+	return []testCase{
+		{
+			name: "index slice",
+			inputExpr: expression.NewIndexExpression(
+				expression.NewVarExpression("items"),
+				expression.NewIntLiteral(1),
+			),
+			inputArgs:  args,
+			wantResult: expression.NewStringLiteral("b"),
+		},
+		{
+			name: "index array",
+			inputExpr: expression.NewIndexExpression(
+				expression.NewVarExpression("array"),
+				expression.NewIntLiteral(0),
+			),
+			inputArgs:  args,
+			wantResult: expression.NewIntLiteral(1),
+		},
+		{
+			name: "index map string",
+			inputExpr: expression.NewIndexExpression(
+				expression.NewVarExpression("headers"),
+				expression.NewStringLiteral("x-id"),
+			),
+			inputArgs:  args,
+			wantResult: expression.NewStringLiteral("1"),
+		},
+		{
+			name: "index map int",
+			inputExpr: expression.NewIndexExpression(
+				expression.NewVarExpression("codes"),
+				expression.NewIntLiteral(1),
+			),
+			inputArgs:  args,
+			wantResult: expression.NewStringLiteral("a"),
+		},
+		{
+			name: "index chain member",
+			inputExpr: expression.NewMemberExpression(
+				expression.NewIndexExpression(
+					expression.NewMemberExpression(expression.NewVarExpression("a"), "b"),
+					expression.NewIntLiteral(0),
+				),
+				"c",
+			),
+			inputArgs:  args,
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name: "index slice of struct pointer",
+			inputExpr: expression.NewMemberExpression(
+				expression.NewIndexExpression(
+					expression.NewVarExpression("users"),
+					expression.NewIntLiteral(0),
+				),
+				"Name",
+			),
+			inputArgs:  args,
+			wantResult: expression.NewStringLiteral("a"),
+		},
+		{
+			name: "index array literal",
+			inputExpr: expression.NewIndexExpression(
+				expression.NewArrayExpression(
+					expression.NewIntLiteral(1),
+					expression.NewIntLiteral(2),
+				),
+				expression.NewBinaryExpression(token.Plus,
+					expression.NewIntLiteral(0),
+					expression.NewIntLiteral(1),
+				),
+			),
+			wantResult: expression.NewIntLiteral(2),
+		},
+		{
+			name: "index out of range",
+			inputExpr: expression.NewIndexExpression(
+				expression.NewVarExpression("items"),
+				expression.NewIntLiteral(2),
+			),
+			inputArgs: args,
+			wantErr:   fmt.Errorf("index 2 out of range length 2 in $items[2]"),
+		},
+		{
+			name: "index negative",
+			inputExpr: expression.NewIndexExpression(
+				expression.NewVarExpression("items"),
+				expression.NewIntLiteral(-1),
+			),
+			inputArgs: args,
+			wantErr:   fmt.Errorf("index -1 out of range length 2 in $items[-1]"),
+		},
+		{
+			name: "index slice by string",
+			inputExpr: expression.NewIndexExpression(
+				expression.NewVarExpression("items"),
+				expression.NewStringLiteral("a"),
+			),
+			inputArgs: args,
+			wantErr:   fmt.Errorf(`can not use "a" as index of []string in $items["a"]`),
+		},
+		{
+			name: "index missing key",
+			inputExpr: expression.NewIndexExpression(
+				expression.NewVarExpression("headers"),
+				expression.NewStringLiteral("x-name"),
+			),
+			inputArgs: args,
+			wantErr:   fmt.Errorf(`missing key "x-name" in $headers["x-name"]`),
+		},
+		{
+			name: "index map wrong key",
+			inputExpr: expression.NewIndexExpression(
+				expression.NewVarExpression("headers"),
+				expression.NewIntLiteral(1),
+			),
+			inputArgs: args,
+			wantErr:   fmt.Errorf("can not use 1 as index of map[string]string in $headers[1]"),
+		},
+		{
+			name: "index struct",
+			inputExpr: expression.NewIndexExpression(
+				expression.NewIndexExpression(
+					expression.NewVarExpression("users"),
+					expression.NewIntLiteral(0),
+				),
+				expression.NewStringLiteral("Name"),
+			),
+			inputArgs:  args,
+			wantResult: expression.NewStringLiteral("a"),
+		},
+		{
+			name: "index struct missing key",
+			inputExpr: expression.NewIndexExpression(
+				expression.NewIndexExpression(
+					expression.NewVarExpression("users"),
+					expression.NewIntLiteral(0),
+				),
+				expression.NewStringLiteral("name"),
+			),
+			inputArgs: args,
+			wantErr:   fmt.Errorf(`missing key "name" in $users[0]["name"]`),
+		},
+		{
+			name: "index struct by int",
+			inputExpr: expression.NewIndexExpression(
+				expression.NewIndexExpression(
+					expression.NewVarExpression("users"),
+					expression.NewIntLiteral(0),
+				),
+				expression.NewIntLiteral(0),
+			),
+			inputArgs: args,
+			wantErr:   fmt.Errorf("can not use 0 as index of evaluate.user in $users[0][0]"),
+		},
+		{
+			name: "index wrong type",
+			inputExpr: expression.NewIndexExpression(
+				expression.NewIntLiteral(1),
+				expression.NewIntLiteral(0),
+			),
+			wantErr: fmt.Errorf("can not access (1)[0] of int64"),
 		},
 	}
 }
@@ -516,22 +1486,192 @@ func generateTestCaseBinary() []testCase {
 	}
 }
 
+// generateTestCaseNull document semantics of null
+// null is only equal to null, compare with null is always false,
+// in null is always false, other operators do not accept null
+func generateTestCaseNull() []testCase {
+	null := expression.NewNullLiteral()
+	varX := expression.NewVarExpression("x")
+	args := map[string]interface{}{
+		"x": nil,
+		"s": (*string)(nil),
+		"user": &user{
+			Name: "a",
+		},
+	}
+
+	return []testCase{
+		{
+			name:       "null",
+			inputExpr:  null,
+			wantResult: null,
+		},
+		{
+			name:       "var nil",
+			inputExpr:  varX,
+			inputArgs:  args,
+			wantResult: null,
+		},
+		{
+			name:       "var nil pointer",
+			inputExpr:  expression.NewVarExpression("s"),
+			inputArgs:  args,
+			wantResult: null,
+		},
+		{
+			name:       "member nil pointer",
+			inputExpr:  expression.NewMemberExpression(expression.NewVarExpression("user"), "profile"),
+			inputArgs:  args,
+			wantResult: null,
+		},
+		{
+			name:       "var slice with nil",
+			inputExpr:  varX,
+			inputArgs:  map[string]interface{}{"x": []interface{}{1, nil}},
+			wantResult: expression.NewArrayExpression(expression.NewIntLiteral(1), null),
+		},
+		{
+			name:       "null == null",
+			inputExpr:  expression.NewBinaryExpression(token.Equal, varX, null),
+			inputArgs:  args,
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name:       "null == int",
+			inputExpr:  expression.NewBinaryExpression(token.Equal, null, expression.NewIntLiteral(0)),
+			wantResult: expression.NewBoolLiteral(false),
+		},
+		{
+			name:       "string == null",
+			inputExpr:  expression.NewBinaryExpression(token.Equal, expression.NewStringLiteral(""), null),
+			wantResult: expression.NewBoolLiteral(false),
+		},
+		{
+			name:       "bool != null",
+			inputExpr:  expression.NewBinaryExpression(token.NotEqual, expression.NewBoolLiteral(false), varX),
+			inputArgs:  args,
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name:       "null != null",
+			inputExpr:  expression.NewBinaryExpression(token.NotEqual, null, null),
+			wantResult: expression.NewBoolLiteral(false),
+		},
+		{
+			name:       "null < int",
+			inputExpr:  expression.NewBinaryExpression(token.Less, varX, expression.NewIntLiteral(1)),
+			inputArgs:  args,
+			wantResult: expression.NewBoolLiteral(false),
+		},
+		{
+			name:       "null >= int",
+			inputExpr:  expression.NewBinaryExpression(token.GreaterOrEqual, varX, expression.NewIntLiteral(1)),
+			inputArgs:  args,
+			wantResult: expression.NewBoolLiteral(false),
+		},
+		{
+			name:       "float <= null",
+			inputExpr:  expression.NewBinaryExpression(token.LessOrEqual, expression.NewFloatLiteral(1.5), null),
+			wantResult: expression.NewBoolLiteral(false),
+		},
+		{
+			name:       "string > null",
+			inputExpr:  expression.NewBinaryExpression(token.Greater, expression.NewStringLiteral("a"), null),
+			wantResult: expression.NewBoolLiteral(false),
+		},
+		{
+			name: "null in array with null",
+			inputExpr: expression.NewBinaryExpression(token.In, varX,
+				expression.NewArrayExpression(expression.NewIntLiteral(1), null)),
+			inputArgs:  args,
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name: "null in array",
+			inputExpr: expression.NewBinaryExpression(token.In, varX,
+				expression.NewArrayExpression(expression.NewIntLiteral(0), expression.NewStringLiteral(""))),
+			inputArgs:  args,
+			wantResult: expression.NewBoolLiteral(false),
+		},
+		{
+			name: "null notin array",
+			inputExpr: expression.NewBinaryExpression(token.NotIn, null,
+				expression.NewArrayExpression(expression.NewBoolLiteral(false))),
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name:       "in null",
+			inputExpr:  expression.NewBinaryExpression(token.In, expression.NewIntLiteral(1), varX),
+			inputArgs:  args,
+			wantResult: expression.NewBoolLiteral(false),
+		},
+		{
+			name:       "notin null",
+			inputExpr:  expression.NewBinaryExpression(token.NotIn, null, null),
+			wantResult: expression.NewBoolLiteral(true),
+		},
+		{
+			name:      "null and",
+			inputExpr: expression.NewBinaryExpression(token.And, varX, expression.NewBoolLiteral(true)),
+			inputArgs: args,
+			wantErr:   fmt.Errorf("expect bool literal got null"),
+		},
+		{
+			name:      "or null",
+			inputExpr: expression.NewBinaryExpression(token.Or, expression.NewBoolLiteral(false), null),
+			wantErr:   fmt.Errorf("expect bool literal got null"),
+		},
+		{
+			name:      "not null",
+			inputExpr: expression.NewUnaryExpression(token.Not, null),
+			wantErr:   fmt.Errorf("expect bool literal got null"),
+		},
+		{
+			name:      "minus null",
+			inputExpr: expression.NewUnaryExpression(token.Minus, varX),
+			inputArgs: args,
+			wantErr:   fmt.Errorf("expect int or float literal got null"),
+		},
+		{
+			name:      "int + null",
+			inputExpr: expression.NewBinaryExpression(token.Plus, expression.NewIntLiteral(1), null),
+			wantErr:   fmt.Errorf("expect int or float literal got null"),
+		},
+		{
+			name:      "member null",
+			inputExpr: expression.NewMemberExpression(varX, "a"),
+			inputArgs: args,
+			wantErr:   fmt.Errorf("can not access $x.a of nil"),
+		},
+	}
+}
+
 func TestEvaluateVisitorVisit(t *testing.T) {
 	var tests []testCase
 	tests = append(tests, generateTestCaseLiteral()...)
 	tests = append(tests, generateTestCaseVar()...)
+	tests = append(tests, generateTestCaseVarSlice()...)
+	tests = append(tests, generateTestCaseArray()...)
+	tests = append(tests, generateTestCaseFloat()...)
+	tests = append(tests, generateTestCaseArithmetic()...)
+	tests = append(tests, generateTestCaseCall()...)
+	tests = append(tests, generateTestCaseMember()...)
+	tests = append(tests, generateTestCaseIndex()...)
 	tests = append(tests, generateTestCaseUnary()...)
 	tests = append(tests, generateTestCaseBinary()...)
+	tests = append(tests, generateTestCaseNull()...)
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			v := NewVisitor(tc.inputArgs)
+			v := NewVisitor(tc.inputArgs, tc.inputOpts...)
 
 			gotResult, gotErr := v.Visit(tc.inputExpr)
-			assert.Equal(t, tc.wantErr, gotErr)
 			if tc.wantErr != nil {
+				// type of error is tested in TestEvaluateVisitorVisitError
+				assert.EqualError(t, gotErr, tc.wantErr.Error())
 				return
 			}
+			assert.NoError(t, gotErr)
 			assert.Equal(t, tc.wantResult, gotResult)
 		})
 	}
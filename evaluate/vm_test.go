@@ -0,0 +1,31 @@
+package evaluate_test
+
+import (
+	"testing"
+
+	"github.com/haunt98/evaluator/evaluate"
+	"github.com/haunt98/evaluator/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+// vm must return the same result and error as visitor
+func TestVMSameAsVisitor(t *testing.T) {
+	for _, tc := range evaluate.SharedTestCases() {
+		t.Run(tc.Name, func(t *testing.T) {
+			v := evaluate.NewVisitor(tc.Args, evaluate.WithRegistry(tc.Registry))
+
+			var wantResult interface{}
+			visitResult, wantErr := v.Visit(tc.Expr)
+			if wantErr == nil {
+				wantResult, wantErr = evaluate.Value(visitResult)
+			}
+
+			prog, err := vm.Compile(tc.Expr, vm.WithRegistry(tc.Registry))
+			assert.NoError(t, err)
+
+			gotResult, gotErr := prog.Run(tc.Args)
+			assert.Equal(t, wantErr, gotErr)
+			assert.Equal(t, wantResult, gotResult)
+		})
+	}
+}
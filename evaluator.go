@@ -0,0 +1,69 @@
+// Package evaluator evaluate expression with args in one call
+package evaluator
+
+import (
+	"fmt"
+)
+
+// Evaluate parse input then evaluate it with args
+// Return go value, see evaluate.Value for detail
+// Use Compile if input is evaluated many times
+func Evaluate(input string, args map[string]interface{}, opts ...Option) (interface{}, error) {
+	prog, err := compile(input, false, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return prog.Eval(args)
+}
+
+func EvaluateBool(input string, args map[string]interface{}, opts ...Option) (bool, error) {
+	return toBool(Evaluate(input, args, opts...))
+}
+
+func EvaluateInt(input string, args map[string]interface{}, opts ...Option) (int64, error) {
+	return toInt(Evaluate(input, args, opts...))
+}
+
+func EvaluateString(input string, args map[string]interface{}, opts ...Option) (string, error) {
+	return toString(Evaluate(input, args, opts...))
+}
+
+func toBool(result interface{}, err error) (bool, error) {
+	if err != nil {
+		return false, err
+	}
+
+	value, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("expect bool got %T", result)
+	}
+
+	return value, nil
+}
+
+func toInt(result interface{}, err error) (int64, error) {
+	if err != nil {
+		return 0, err
+	}
+
+	value, ok := result.(int64)
+	if !ok {
+		return 0, fmt.Errorf("expect int64 got %T", result)
+	}
+
+	return value, nil
+}
+
+func toString(result interface{}, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := result.(string)
+	if !ok {
+		return "", fmt.Errorf("expect string got %T", result)
+	}
+
+	return value, nil
+}
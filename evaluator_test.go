@@ -0,0 +1,248 @@
+package evaluator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/haunt98/evaluator/builtin"
+	"github.com/haunt98/evaluator/evaluate"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCase struct {
+	name       string
+	input      string
+	inputArgs  map[string]interface{}
+	wantResult interface{}
+	wantErr    bool
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []testCase{
+		{
+			name:       "bool",
+			input:      "true",
+			wantResult: true,
+		},
+		{
+			name:       "int",
+			input:      "1",
+			wantResult: int64(1),
+		},
+		{
+			name:       "float",
+			input:      "1.5",
+			wantResult: 1.5,
+		},
+		{
+			name:  "float compare",
+			input: "$score >= 0.75",
+			inputArgs: map[string]interface{}{
+				"score": 0.8,
+			},
+			wantResult: true,
+		},
+		{
+			name:       "string",
+			input:      `"a"`,
+			wantResult: "a",
+		},
+		{
+			name:       "array",
+			input:      `[1, "a", true]`,
+			wantResult: []interface{}{int64(1), "a", true},
+		},
+		{
+			name:  "complex",
+			input: `($x or $y) and $z in [1, 2]`,
+			inputArgs: map[string]interface{}{
+				"x": false,
+				"y": true,
+				"z": 2,
+			},
+			wantResult: true,
+		},
+		{
+			name:  "in array with var",
+			input: `$x in [$a, $b]`,
+			inputArgs: map[string]interface{}{
+				"x": "b",
+				"a": "a",
+				"b": "b",
+			},
+			wantResult: true,
+		},
+		{
+			name:  "arithmetic",
+			input: "$price * $qty > 1000",
+			inputArgs: map[string]interface{}{
+				"price": 300,
+				"qty":   4,
+			},
+			wantResult: true,
+		},
+		{
+			name:  "member",
+			input: `$user.profile.country == "vn"`,
+			inputArgs: map[string]interface{}{
+				"user": map[string]interface{}{
+					"profile": map[string]interface{}{
+						"country": "vn",
+					},
+				},
+			},
+			wantResult: true,
+		},
+		{
+			name:  "index",
+			input: `$items[0] == "a" and $headers["x-id"] == "1"`,
+			inputArgs: map[string]interface{}{
+				"items": []string{"a"},
+				"headers": map[string]string{
+					"x-id": "1",
+				},
+			},
+			wantResult: true,
+		},
+		{
+			name:  "in slice",
+			input: `"admin" in $roles and $role in $allowedRoles`,
+			inputArgs: map[string]interface{}{
+				"roles":        []string{"admin"},
+				"role":         "editor",
+				"allowedRoles": []string{"admin", "editor"},
+			},
+			wantResult: true,
+		},
+		{
+			name:    "division by zero",
+			input:   "1 / 0",
+			wantErr: true,
+		},
+		{
+			name:    "parse error",
+			input:   "(true",
+			wantErr: true,
+		},
+		{
+			name:    "trailing token",
+			input:   "true false",
+			wantErr: true,
+		},
+		{
+			name:    "args missing",
+			input:   "$x",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotResult, gotErr := Evaluate(tc.input, tc.inputArgs)
+			if tc.wantErr {
+				assert.Error(t, gotErr)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tc.wantResult, gotResult)
+		})
+	}
+}
+
+func TestEvaluateBool(t *testing.T) {
+	gotResult, gotErr := EvaluateBool("$x == 1", map[string]interface{}{
+		"x": 1,
+	})
+	assert.NoError(t, gotErr)
+	assert.True(t, gotResult)
+
+	_, gotErr = EvaluateBool("1", nil)
+	assert.Error(t, gotErr)
+}
+
+func TestEvaluateInt(t *testing.T) {
+	gotResult, gotErr := EvaluateInt("$x", map[string]interface{}{
+		"x": 1,
+	})
+	assert.NoError(t, gotErr)
+	assert.Equal(t, int64(1), gotResult)
+
+	_, gotErr = EvaluateInt("true", nil)
+	assert.Error(t, gotErr)
+}
+
+func TestEvaluateString(t *testing.T) {
+	gotResult, gotErr := EvaluateString(`"a"`, nil)
+	assert.NoError(t, gotErr)
+	assert.Equal(t, "a", gotResult)
+
+	_, gotErr = EvaluateString("1", nil)
+	assert.Error(t, gotErr)
+}
+
+func TestEvaluateBuiltin(t *testing.T) {
+	gotResult, gotErr := EvaluateInt(`len($name)`, map[string]interface{}{
+		"name": "abc",
+	})
+	assert.NoError(t, gotErr)
+	assert.Equal(t, int64(3), gotResult)
+
+	gotBool, gotErr := EvaluateBool(`matches($phone, "^0[0-9]+$")`, map[string]interface{}{
+		"phone": "0123",
+	})
+	assert.NoError(t, gotErr)
+	assert.True(t, gotBool)
+}
+
+func TestEvaluateWithRegistry(t *testing.T) {
+	twice := evaluate.Function{
+		Name:   "twice",
+		Params: []evaluate.Kind{evaluate.StringKind},
+		Result: evaluate.StringKind,
+		Fn: func(args ...interface{}) (interface{}, error) {
+			return strings.Repeat(args[0].(string), 2), nil
+		},
+	}
+
+	args := map[string]interface{}{
+		"country": "VN",
+	}
+
+	_, gotErr := EvaluateBool(`twice($country) == "VNVN"`, args)
+	assert.Error(t, gotErr)
+
+	// registry replace builtin functions
+	registry := evaluate.NewRegistry()
+	assert.NoError(t, registry.Register(twice))
+
+	gotResult, gotErr := EvaluateBool(`twice($country) == "VNVN"`, args, WithRegistry(registry))
+	assert.NoError(t, gotErr)
+	assert.True(t, gotResult)
+
+	_, gotErr = EvaluateBool(`lower($country) == "vn"`, args, WithRegistry(registry))
+	assert.Error(t, gotErr)
+
+	// registry extend builtin functions
+	registry = builtin.NewRegistry()
+	assert.NoError(t, registry.Register(twice))
+
+	gotResult, gotErr = EvaluateBool(`lower(twice($country)) == "vnvn"`, args, WithRegistry(registry))
+	assert.NoError(t, gotErr)
+	assert.True(t, gotResult)
+}
+
+func TestEvaluateErrorAs(t *testing.T) {
+	_, gotErr := Evaluate("$x / 0", map[string]interface{}{
+		"x": 1,
+	})
+
+	var divErr *evaluate.DivisionByZeroError
+	assert.True(t, errors.As(gotErr, &divErr))
+
+	_, gotErr = Evaluate("$x", nil)
+
+	var missingErr *evaluate.MissingVariableError
+	assert.True(t, errors.As(gotErr, &missingErr))
+	assert.Equal(t, "x", missingErr.Name)
+}
@@ -1,6 +1,7 @@
 package expression
 
 import (
+	"encoding/json"
 	"strings"
 )
 
@@ -32,5 +33,38 @@ func (expr *ArrayExpression) String() string {
 }
 
 func (expr *ArrayExpression) Accept(v Visitor) (Expression, error) {
-	return v.VisitLiteral(expr)
+	return v.VisitArray(expr)
+}
+
+func (expr *ArrayExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type     string       `json:"type"`
+		Children []Expression `json:"children"`
+	}{
+		Type:     arrayType,
+		Children: expr.Children,
+	})
+}
+
+func (expr *ArrayExpression) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type     string            `json:"type"`
+		Children []json.RawMessage `json:"children"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if err := checkType(arrayType, aux.Type); err != nil {
+		return err
+	}
+
+	children, err := unmarshalList(aux.Children)
+	if err != nil {
+		return err
+	}
+
+	expr.Children = children
+
+	return nil
 }
@@ -0,0 +1,37 @@
+package expression
+
+import (
+	"errors"
+)
+
+var _ Expression = (*BadExpression)(nil)
+
+// BadExpression is placeholder for expression which has syntax error
+// It is only created when parser recovers from error
+type BadExpression struct {
+	Err error
+}
+
+func NewBadExpression(err error) *BadExpression {
+	return &BadExpression{
+		Err: err,
+	}
+}
+
+func (expr *BadExpression) String() string {
+	return "Bad"
+}
+
+// Accept always return error because bad expression can not be visited
+func (expr *BadExpression) Accept(_ Visitor) (Expression, error) {
+	if expr.Err == nil {
+		return nil, errors.New("bad expression")
+	}
+
+	return nil, expr.Err
+}
+
+// MarshalJSON always return error because bad expression is not valid expression
+func (expr *BadExpression) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("can not marshal bad expression")
+}
@@ -1,6 +1,8 @@
 package expression
 
 import (
+	"encoding/json"
+
 	"github.com/haunt98/evaluator/token"
 )
 
@@ -26,3 +28,49 @@ func (expr *BinaryExpression) String() string {
 func (expr *BinaryExpression) Accept(v Visitor) (Expression, error) {
 	return v.VisitBinary(expr)
 }
+
+func (expr *BinaryExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type     string      `json:"type"`
+		Operator token.Token `json:"operator"`
+		Left     Expression  `json:"left"`
+		Right    Expression  `json:"right"`
+	}{
+		Type:     binaryType,
+		Operator: expr.Operator,
+		Left:     expr.Left,
+		Right:    expr.Right,
+	})
+}
+
+func (expr *BinaryExpression) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type     string          `json:"type"`
+		Operator token.Token     `json:"operator"`
+		Left     json.RawMessage `json:"left"`
+		Right    json.RawMessage `json:"right"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if err := checkType(binaryType, aux.Type); err != nil {
+		return err
+	}
+
+	left, err := Unmarshal(aux.Left)
+	if err != nil {
+		return err
+	}
+
+	right, err := Unmarshal(aux.Right)
+	if err != nil {
+		return err
+	}
+
+	expr.Operator = aux.Operator
+	expr.Left = left
+	expr.Right = right
+
+	return nil
+}
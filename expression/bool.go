@@ -1,6 +1,7 @@
 package expression
 
 import (
+	"encoding/json"
 	"strconv"
 )
 
@@ -23,3 +24,31 @@ func (lit *BoolLiteral) String() string {
 func (lit *BoolLiteral) Accept(v Visitor) (Expression, error) {
 	return v.VisitLiteral(lit)
 }
+
+func (lit *BoolLiteral) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type  string `json:"type"`
+		Value bool   `json:"value"`
+	}{
+		Type:  boolType,
+		Value: lit.Value,
+	})
+}
+
+func (lit *BoolLiteral) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type  string `json:"type"`
+		Value bool   `json:"value"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if err := checkType(boolType, aux.Type); err != nil {
+		return err
+	}
+
+	lit.Value = aux.Value
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package expression
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+var _ Expression = (*CallExpression)(nil)
+
+type CallExpression struct {
+	Name string
+	Args []Expression
+}
+
+func NewCallExpression(name string, args ...Expression) *CallExpression {
+	if len(args) == 0 {
+		return &CallExpression{
+			Name: name,
+			Args: []Expression{},
+		}
+	}
+
+	return &CallExpression{
+		Name: name,
+		Args: args,
+	}
+}
+
+func (expr *CallExpression) String() string {
+	argsRepresent := make([]string, len(expr.Args))
+	for i, arg := range expr.Args {
+		argsRepresent[i] = arg.String()
+	}
+
+	return expr.Name + "(" + strings.Join(argsRepresent, ", ") + ")"
+}
+
+func (expr *CallExpression) Accept(v Visitor) (Expression, error) {
+	return v.VisitCall(expr)
+}
+
+func (expr *CallExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string       `json:"type"`
+		Name string       `json:"name"`
+		Args []Expression `json:"args"`
+	}{
+		Type: callType,
+		Name: expr.Name,
+		Args: expr.Args,
+	})
+}
+
+func (expr *CallExpression) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type string            `json:"type"`
+		Name string            `json:"name"`
+		Args []json.RawMessage `json:"args"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if err := checkType(callType, aux.Type); err != nil {
+		return err
+	}
+
+	args, err := unmarshalList(aux.Args)
+	if err != nil {
+		return err
+	}
+
+	expr.Name = aux.Name
+	expr.Args = args
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package expression
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+var _ Expression = (*FloatLiteral)(nil)
+
+type FloatLiteral struct {
+	Value float64
+}
+
+func NewFloatLiteral(value float64) *FloatLiteral {
+	return &FloatLiteral{
+		Value: value,
+	}
+}
+
+func (lit *FloatLiteral) String() string {
+	return strconv.FormatFloat(lit.Value, 'g', -1, 64)
+}
+
+func (lit *FloatLiteral) Accept(v Visitor) (Expression, error) {
+	return v.VisitLiteral(lit)
+}
+
+// MarshalJSON encode NaN, +Inf, -Inf as string because json number does not support them
+func (lit *FloatLiteral) MarshalJSON() ([]byte, error) {
+	var value interface{} = lit.Value
+	if math.IsNaN(lit.Value) || math.IsInf(lit.Value, 0) {
+		value = strconv.FormatFloat(lit.Value, 'g', -1, 64)
+	}
+
+	return json.Marshal(struct {
+		Type  string      `json:"type"`
+		Value interface{} `json:"value"`
+	}{
+		Type:  floatType,
+		Value: value,
+	})
+}
+
+func (lit *FloatLiteral) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if err := checkType(floatType, aux.Type); err != nil {
+		return err
+	}
+
+	var s string
+	if err := json.Unmarshal(aux.Value, &s); err == nil {
+		value, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+
+		lit.Value = value
+
+		return nil
+	}
+
+	return json.Unmarshal(aux.Value, &lit.Value)
+}
@@ -0,0 +1,71 @@
+package expression
+
+import (
+	"encoding/json"
+
+	"github.com/haunt98/evaluator/token"
+)
+
+var _ Expression = (*IndexExpression)(nil)
+
+type IndexExpression struct {
+	Object Expression
+	Index  Expression
+}
+
+func NewIndexExpression(object, index Expression) *IndexExpression {
+	return &IndexExpression{
+		Object: object,
+		Index:  index,
+	}
+}
+
+func (expr *IndexExpression) String() string {
+	return expr.Object.String() + token.OpenSquareBracket.String() + expr.Index.String() + token.CloseSquareBracket.String()
+}
+
+func (expr *IndexExpression) Accept(v Visitor) (Expression, error) {
+	return v.VisitIndex(expr)
+}
+
+func (expr *IndexExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type   string     `json:"type"`
+		Object Expression `json:"object"`
+		Index  Expression `json:"index"`
+	}{
+		Type:   indexType,
+		Object: expr.Object,
+		Index:  expr.Index,
+	})
+}
+
+func (expr *IndexExpression) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type   string          `json:"type"`
+		Object json.RawMessage `json:"object"`
+		Index  json.RawMessage `json:"index"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if err := checkType(indexType, aux.Type); err != nil {
+		return err
+	}
+
+	object, err := Unmarshal(aux.Object)
+	if err != nil {
+		return err
+	}
+
+	index, err := Unmarshal(aux.Index)
+	if err != nil {
+		return err
+	}
+
+	expr.Object = object
+	expr.Index = index
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package expression
 
 import (
+	"encoding/json"
 	"strconv"
 )
 
@@ -23,3 +24,31 @@ func (lit *IntLiteral) String() string {
 func (lit *IntLiteral) Accept(v Visitor) (Expression, error) {
 	return v.VisitLiteral(lit)
 }
+
+func (lit *IntLiteral) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type  string `json:"type"`
+		Value int64  `json:"value"`
+	}{
+		Type:  intType,
+		Value: lit.Value,
+	})
+}
+
+func (lit *IntLiteral) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type  string `json:"type"`
+		Value int64  `json:"value"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if err := checkType(intType, aux.Type); err != nil {
+		return err
+	}
+
+	lit.Value = aux.Value
+
+	return nil
+}
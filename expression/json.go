@@ -0,0 +1,95 @@
+package expression
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// type of expression in json
+// {"type": "binary", "operator": "And", "left": {...}, "right": {...}}
+const (
+	boolType   = "bool"
+	intType    = "int"
+	floatType  = "float"
+	stringType = "string"
+	nullType   = "null"
+	varType    = "var"
+	arrayType  = "array"
+	setType    = "set"
+	unaryType  = "unary"
+	binaryType = "binary"
+	callType   = "call"
+	memberType = "member"
+	indexType  = "index"
+)
+
+// Unmarshal decode expression from json which is encoded by json.Marshal
+func Unmarshal(data []byte) (Expression, error) {
+	var typed struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, err
+	}
+
+	var expr Expression
+	switch typed.Type {
+	case boolType:
+		expr = &BoolLiteral{}
+	case intType:
+		expr = &IntLiteral{}
+	case floatType:
+		expr = &FloatLiteral{}
+	case stringType:
+		expr = &StringLiteral{}
+	case nullType:
+		expr = &NullLiteral{}
+	case varType:
+		expr = &VarExpression{}
+	case arrayType:
+		expr = &ArrayExpression{}
+	case setType:
+		expr = &SetLiteral{}
+	case unaryType:
+		expr = &UnaryExpression{}
+	case binaryType:
+		expr = &BinaryExpression{}
+	case callType:
+		expr = &CallExpression{}
+	case memberType:
+		expr = &MemberExpression{}
+	case indexType:
+		expr = &IndexExpression{}
+	default:
+		return nil, fmt.Errorf("not implement expression type %s", typed.Type)
+	}
+
+	if err := json.Unmarshal(data, expr); err != nil {
+		return nil, err
+	}
+
+	return expr, nil
+}
+
+func checkType(expected, got string) error {
+	if expected != got {
+		return fmt.Errorf("expect expression type %s got %s", expected, got)
+	}
+
+	return nil
+}
+
+// unmarshalList decode list of expressions, empty list is not nil
+func unmarshalList(list []json.RawMessage) ([]Expression, error) {
+	exprs := make([]Expression, len(list))
+	for i, data := range list {
+		expr, err := Unmarshal(data)
+		if err != nil {
+			return nil, err
+		}
+
+		exprs[i] = expr
+	}
+
+	return exprs, nil
+}
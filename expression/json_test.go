@@ -0,0 +1,160 @@
+package expression
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/haunt98/evaluator/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		expr Expression
+	}{
+		{
+			name: "bool",
+			expr: NewBoolLiteral(true),
+		},
+		{
+			name: "max int",
+			expr: NewIntLiteral(math.MaxInt64),
+		},
+		{
+			name: "min int",
+			expr: NewIntLiteral(math.MinInt64),
+		},
+		{
+			name: "float",
+			expr: NewFloatLiteral(0.1),
+		},
+		{
+			name: "float without fraction",
+			expr: NewFloatLiteral(2),
+		},
+		{
+			name: "negative zero",
+			expr: NewFloatLiteral(math.Copysign(0, -1)),
+		},
+		{
+			name: "inf",
+			expr: NewFloatLiteral(math.Inf(-1)),
+		},
+		{
+			name: "string",
+			expr: NewStringLiteral("a \"b\" \n"),
+		},
+		{
+			name: "null",
+			expr: NewNullLiteral(),
+		},
+		{
+			name: "var",
+			expr: NewVarExpression("x"),
+		},
+		{
+			name: "empty array",
+			expr: NewArrayExpression(),
+		},
+		{
+			name: "array",
+			expr: NewArrayExpression(NewIntLiteral(1), NewArrayExpression(NewStringLiteral("a"))),
+		},
+		{
+			name: "set",
+			expr: NewSetLiteral(NewIntLiteral(1), NewFloatLiteral(1.5), NewStringLiteral("a"), NewNullLiteral()),
+		},
+		{
+			name: "unary",
+			expr: NewUnaryExpression(token.Not, NewVarExpression("x")),
+		},
+		{
+			name: "binary",
+			expr: NewBinaryExpression(token.And,
+				NewBinaryExpression(token.GreaterOrEqual, NewVarExpression("x"), NewFloatLiteral(1.5)),
+				NewBinaryExpression(token.NotIn, NewVarExpression("y"), NewArrayExpression(NewIntLiteral(1))),
+			),
+		},
+		{
+			name: "call",
+			expr: NewCallExpression("len", NewVarExpression("x")),
+		},
+		{
+			name: "call without args",
+			expr: NewCallExpression("now"),
+		},
+		{
+			name: "member and index",
+			expr: NewIndexExpression(NewMemberExpression(NewVarExpression("user"), "roles"), NewIntLiteral(0)),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := json.Marshal(tc.expr)
+			assert.NoError(t, err)
+
+			got, err := Unmarshal(data)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expr, got)
+		})
+	}
+}
+
+func TestJSONSpecialFloat(t *testing.T) {
+	data, err := json.Marshal(NewFloatLiteral(math.NaN()))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type": "float", "value": "NaN"}`, string(data))
+
+	got, err := Unmarshal(data)
+	assert.NoError(t, err)
+	assert.True(t, math.IsNaN(got.(*FloatLiteral).Value))
+
+	data, err = json.Marshal(NewFloatLiteral(math.Copysign(0, -1)))
+	assert.NoError(t, err)
+
+	got, err = Unmarshal(data)
+	assert.NoError(t, err)
+	assert.True(t, math.Signbit(got.(*FloatLiteral).Value))
+}
+
+func TestJSONSchema(t *testing.T) {
+	expr := NewBinaryExpression(token.Equal, NewVarExpression("x"), NewUnaryExpression(token.Minus, NewIntLiteral(1)))
+
+	data, err := json.Marshal(expr)
+	assert.NoError(t, err)
+
+	want := `{
+		"type": "binary",
+		"operator": "Equal",
+		"left": {"type": "var", "name": "x"},
+		"right": {"type": "unary", "operator": "Minus", "child": {"type": "int", "value": 1}}
+	}`
+	assert.JSONEq(t, want, string(data))
+}
+
+func TestJSONError(t *testing.T) {
+	tests := []string{
+		`{"type": "unknown"}`,
+		`{"type": "binary", "operator": "Unknown", "left": {"type": "int", "value": 1}, "right": {"type": "int", "value": 1}}`,
+		`{"type": "unary", "operator": "Not"}`,
+		`{"type": "int", "value": 1.5}`,
+		`[]`,
+	}
+
+	for _, tc := range tests {
+		t.Run(tc, func(t *testing.T) {
+			_, err := Unmarshal([]byte(tc))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := json.Marshal(NewBadExpression(nil))
+	assert.Error(t, err)
+
+	// type must match node
+	err = json.Unmarshal([]byte(`{"type": "int", "value": 1}`), &BoolLiteral{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package expression
+
+import (
+	"encoding/json"
+
+	"github.com/haunt98/evaluator/token"
+)
+
+var _ Expression = (*MemberExpression)(nil)
+
+type MemberExpression struct {
+	Object Expression
+	Field  string
+}
+
+func NewMemberExpression(object Expression, field string) *MemberExpression {
+	return &MemberExpression{
+		Object: object,
+		Field:  field,
+	}
+}
+
+func (expr *MemberExpression) String() string {
+	return expr.Object.String() + token.Dot.String() + expr.Field
+}
+
+func (expr *MemberExpression) Accept(v Visitor) (Expression, error) {
+	return v.VisitMember(expr)
+}
+
+func (expr *MemberExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type   string     `json:"type"`
+		Object Expression `json:"object"`
+		Field  string     `json:"field"`
+	}{
+		Type:   memberType,
+		Object: expr.Object,
+		Field:  expr.Field,
+	})
+}
+
+func (expr *MemberExpression) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type   string          `json:"type"`
+		Object json.RawMessage `json:"object"`
+		Field  string          `json:"field"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if err := checkType(memberType, aux.Type); err != nil {
+		return err
+	}
+
+	object, err := Unmarshal(aux.Object)
+	if err != nil {
+		return err
+	}
+
+	expr.Object = object
+	expr.Field = aux.Field
+
+	return nil
+}
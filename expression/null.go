@@ -0,0 +1,41 @@
+package expression
+
+import (
+	"encoding/json"
+)
+
+var _ Expression = (*NullLiteral)(nil)
+
+// NullLiteral is null keyword or nil var
+type NullLiteral struct{}
+
+func NewNullLiteral() *NullLiteral {
+	return &NullLiteral{}
+}
+
+func (lit *NullLiteral) String() string {
+	return "null"
+}
+
+func (lit *NullLiteral) Accept(v Visitor) (Expression, error) {
+	return v.VisitLiteral(lit)
+}
+
+func (lit *NullLiteral) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string `json:"type"`
+	}{
+		Type: nullType,
+	})
+}
+
+func (lit *NullLiteral) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	return checkType(nullType, aux.Type)
+}
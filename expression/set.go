@@ -0,0 +1,111 @@
+package expression
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/haunt98/evaluator/internal/set"
+)
+
+var _ Expression = (*SetLiteral)(nil)
+
+// SetLiteral is array of literals with hash set so in, notin do not compare each child
+// It is created by optimize for right of in, notin, see optimize.Optimize
+type SetLiteral struct {
+	Children []Expression
+	set      *set.Set
+}
+
+// NewSetLiteral return set of children, child which is not bool, int, float, string or null literal
+// is not equal to anything so it is not in set
+func NewSetLiteral(children ...Expression) *SetLiteral {
+	s := set.New()
+	for _, child := range children {
+		switch c := child.(type) {
+		case *BoolLiteral:
+			s.AddBool(c.Value)
+		case *IntLiteral:
+			s.AddInt(c.Value)
+		case *FloatLiteral:
+			s.AddFloat(c.Value)
+		case *StringLiteral:
+			s.AddString(c.Value)
+		case *NullLiteral:
+			s.AddNull()
+		}
+	}
+
+	if children == nil {
+		children = []Expression{}
+	}
+
+	return &SetLiteral{
+		Children: children,
+		set:      s,
+	}
+}
+
+// Contains return true if expr is equal to any child
+// int and float are compared as float64, child with different type is not equal
+func (lit *SetLiteral) Contains(expr Expression) bool {
+	switch e := expr.(type) {
+	case *BoolLiteral:
+		return lit.set.ContainsBool(e.Value)
+	case *IntLiteral:
+		return lit.set.ContainsInt(e.Value)
+	case *FloatLiteral:
+		return lit.set.ContainsFloat(e.Value)
+	case *StringLiteral:
+		return lit.set.ContainsString(e.Value)
+	case *NullLiteral:
+		return lit.set.ContainsNull()
+	default:
+		return false
+	}
+}
+
+func (lit *SetLiteral) String() string {
+	childrenRepresent := make([]string, len(lit.Children))
+	for i, child := range lit.Children {
+		childrenRepresent[i] = child.String()
+	}
+
+	return "Set[" + strings.Join(childrenRepresent, " ,") + "]"
+}
+
+func (lit *SetLiteral) Accept(v Visitor) (Expression, error) {
+	return v.VisitLiteral(lit)
+}
+
+func (lit *SetLiteral) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type     string       `json:"type"`
+		Children []Expression `json:"children"`
+	}{
+		Type:     setType,
+		Children: lit.Children,
+	})
+}
+
+func (lit *SetLiteral) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type     string            `json:"type"`
+		Children []json.RawMessage `json:"children"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if err := checkType(setType, aux.Type); err != nil {
+		return err
+	}
+
+	children, err := unmarshalList(aux.Children)
+	if err != nil {
+		return err
+	}
+
+	*lit = *NewSetLiteral(children...)
+
+	return nil
+}
@@ -1,5 +1,9 @@
 package expression
 
+import (
+	"encoding/json"
+)
+
 var _ Expression = (*StringLiteral)(nil)
 
 type StringLiteral struct {
@@ -19,3 +23,31 @@ func (lit *StringLiteral) String() string {
 func (lit *StringLiteral) Accept(v Visitor) (Expression, error) {
 	return v.VisitLiteral(lit)
 }
+
+func (lit *StringLiteral) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}{
+		Type:  stringType,
+		Value: lit.Value,
+	})
+}
+
+func (lit *StringLiteral) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if err := checkType(stringType, aux.Type); err != nil {
+		return err
+	}
+
+	lit.Value = aux.Value
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package expression
 
 import (
+	"encoding/json"
+
 	"github.com/haunt98/evaluator/token"
 )
 
@@ -25,3 +27,40 @@ func (expr *UnaryExpression) String() string {
 func (expr *UnaryExpression) Accept(v Visitor) (Expression, error) {
 	return v.VisitUnary(expr)
 }
+
+func (expr *UnaryExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type     string      `json:"type"`
+		Operator token.Token `json:"operator"`
+		Child    Expression  `json:"child"`
+	}{
+		Type:     unaryType,
+		Operator: expr.Operator,
+		Child:    expr.Child,
+	})
+}
+
+func (expr *UnaryExpression) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type     string          `json:"type"`
+		Operator token.Token     `json:"operator"`
+		Child    json.RawMessage `json:"child"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if err := checkType(unaryType, aux.Type); err != nil {
+		return err
+	}
+
+	child, err := Unmarshal(aux.Child)
+	if err != nil {
+		return err
+	}
+
+	expr.Operator = aux.Operator
+	expr.Child = child
+
+	return nil
+}
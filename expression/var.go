@@ -1,6 +1,8 @@
 package expression
 
 import (
+	"encoding/json"
+
 	"github.com/haunt98/evaluator/token"
 )
 
@@ -23,3 +25,31 @@ func (expr *VarExpression) String() string {
 func (expr *VarExpression) Accept(v Visitor) (Expression, error) {
 	return v.VisitVar(expr)
 }
+
+func (expr *VarExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+	}{
+		Type: varType,
+		Name: expr.Value,
+	})
+}
+
+func (expr *VarExpression) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if err := checkType(varType, aux.Type); err != nil {
+		return err
+	}
+
+	expr.Value = aux.Name
+
+	return nil
+}
@@ -5,6 +5,10 @@ type Visitor interface {
 
 	VisitLiteral(expr Expression) (Expression, error)
 	VisitVar(expr *VarExpression) (Expression, error)
+	VisitArray(expr *ArrayExpression) (Expression, error)
 	VisitUnary(expr *UnaryExpression) (Expression, error)
 	VisitBinary(expr *BinaryExpression) (Expression, error)
+	VisitCall(expr *CallExpression) (Expression, error)
+	VisitMember(expr *MemberExpression) (Expression, error)
+	VisitIndex(expr *IndexExpression) (Expression, error)
 }
@@ -0,0 +1,282 @@
+// Package formatter print expression as input which is parsed to the same expression
+// Parentheses are only added when they are needed
+package formatter
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/scanner"
+	"github.com/haunt98/evaluator/token"
+)
+
+// atomLevel is precedence of expression which never needs parentheses
+// such as literal, var, call
+const atomLevel = math.MaxInt32
+
+// keywords are used instead of token String which is for debug
+var keywords = map[token.Token]string{
+	token.Or:             "or",
+	token.And:            "and",
+	token.Equal:          "==",
+	token.NotEqual:       "!=",
+	token.Less:           "<",
+	token.LessOrEqual:    "<=",
+	token.Greater:        ">",
+	token.GreaterOrEqual: ">=",
+	token.In:             "in",
+	token.NotIn:          "notin",
+	token.Not:            "!",
+	token.Plus:           "+",
+	token.Minus:          "-",
+	token.Multiply:       "*",
+	token.Divide:         "/",
+	token.Modulo:         "%",
+}
+
+// Format return input of expression
+// Return error if expression can not be written as input
+// such as bad expression, NaN, var name which is not ident
+func Format(expr expression.Expression) (string, error) {
+	var sb strings.Builder
+	if err := format(&sb, expr, true); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// format write expr to sb
+// isRightmost is false if expr is followed by other tokens which are not , ) ]
+// because ! takes all tokens after it
+func format(sb *strings.Builder, expr expression.Expression, isRightmost bool) error {
+	switch e := expr.(type) {
+	case *expression.BoolLiteral:
+		sb.WriteString(strconv.FormatBool(e.Value))
+	case *expression.IntLiteral:
+		sb.WriteString(strconv.FormatInt(e.Value, 10))
+	case *expression.FloatLiteral:
+		s, err := formatFloat(e.Value)
+		if err != nil {
+			return err
+		}
+
+		sb.WriteString(s)
+	case *expression.StringLiteral:
+		sb.WriteString(strconv.Quote(e.Value))
+	case *expression.NullLiteral:
+		sb.WriteString("null")
+	case *expression.VarExpression:
+		if !isToken("$"+e.Value, token.Var, e.Value) {
+			return fmt.Errorf("can not format var name %s", e.Value)
+		}
+
+		sb.WriteString("$" + e.Value)
+	case *expression.ArrayExpression:
+		sb.WriteString("[")
+		if err := formatList(sb, e.Children); err != nil {
+			return err
+		}
+		sb.WriteString("]")
+	case *expression.SetLiteral:
+		// set is written as array, it is parsed to array
+		sb.WriteString("[")
+		if err := formatList(sb, e.Children); err != nil {
+			return err
+		}
+		sb.WriteString("]")
+	case *expression.UnaryExpression:
+		return formatUnary(sb, e, isRightmost)
+	case *expression.BinaryExpression:
+		return formatBinary(sb, e, isRightmost)
+	case *expression.CallExpression:
+		if !isToken(e.Name, token.Ident, e.Name) {
+			return fmt.Errorf("can not format function name %s", e.Name)
+		}
+
+		sb.WriteString(e.Name + "(")
+		if err := formatList(sb, e.Args); err != nil {
+			return err
+		}
+		sb.WriteString(")")
+	case *expression.MemberExpression:
+		if !isField(e.Field) {
+			return fmt.Errorf("can not format field %s", e.Field)
+		}
+
+		if err := formatObject(sb, e.Object); err != nil {
+			return err
+		}
+
+		sb.WriteString("." + e.Field)
+	case *expression.IndexExpression:
+		if err := formatObject(sb, e.Object); err != nil {
+			return err
+		}
+
+		sb.WriteString("[")
+		if err := format(sb, e.Index, true); err != nil {
+			return err
+		}
+		sb.WriteString("]")
+	default:
+		return fmt.Errorf("can not format expression %T", expr)
+	}
+
+	return nil
+}
+
+func formatUnary(sb *strings.Builder, expr *expression.UnaryExpression, isRightmost bool) error {
+	switch expr.Operator {
+	case token.Not:
+		// !$a and $b is parsed as !($a and $b)
+		if !isRightmost {
+			return formatParenthesis(sb, expr)
+		}
+
+		sb.WriteString(keywords[token.Not])
+
+		// operand of ! is parsed with lowest precedence so it never needs parentheses
+		return format(sb, expr.Child, true)
+	case token.Minus:
+		sb.WriteString(keywords[token.Minus])
+
+		// -1 is parsed as int literal, not unary of int literal
+		if isNumber(expr.Child) || precedence(expr.Child) < token.PrefixLevel {
+			return formatParenthesis(sb, expr.Child)
+		}
+
+		return format(sb, expr.Child, isRightmost)
+	default:
+		return fmt.Errorf("can not format unary operator %s", expr.Operator)
+	}
+}
+
+func formatBinary(sb *strings.Builder, expr *expression.BinaryExpression, isRightmost bool) error {
+	keyword, ok := keywords[expr.Operator]
+	if !ok || expr.Operator == token.Not {
+		return fmt.Errorf("can not format binary operator %s", expr.Operator)
+	}
+
+	level := expr.Operator.Precedence()
+
+	// binary is left associative
+	// left with the same precedence does not need parentheses but right does
+	if precedence(expr.Left) < level {
+		if err := formatParenthesis(sb, expr.Left); err != nil {
+			return err
+		}
+	} else if err := format(sb, expr.Left, false); err != nil {
+		return err
+	}
+
+	sb.WriteString(" " + keyword + " ")
+
+	if precedence(expr.Right) <= level {
+		return formatParenthesis(sb, expr.Right)
+	}
+
+	return format(sb, expr.Right, isRightmost)
+}
+
+// formatObject write object of member, index
+func formatObject(sb *strings.Builder, object expression.Expression) error {
+	// 1.a is scanned as float 1. and ident a
+	if isNumber(object) || precedence(object) < atomLevel {
+		return formatParenthesis(sb, object)
+	}
+
+	return format(sb, object, false)
+}
+
+func formatParenthesis(sb *strings.Builder, expr expression.Expression) error {
+	sb.WriteString("(")
+	if err := format(sb, expr, true); err != nil {
+		return err
+	}
+	sb.WriteString(")")
+
+	return nil
+}
+
+func formatList(sb *strings.Builder, exprs []expression.Expression) error {
+	for i, expr := range exprs {
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+
+		if err := format(sb, expr, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// formatFloat always has . or e so it is not parsed as int
+func formatFloat(value float64) (string, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return "", fmt.Errorf("can not format float %v", value)
+	}
+
+	s := strconv.FormatFloat(value, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+
+	return s, nil
+}
+
+// precedence return how tight expr is bound when it is operand
+func precedence(expr expression.Expression) int {
+	switch e := expr.(type) {
+	case *expression.BinaryExpression:
+		return e.Operator.Precedence()
+	case *expression.UnaryExpression:
+		if e.Operator == token.Minus {
+			return token.PrefixLevel
+		}
+
+		// ! is handled by isRightmost
+		return atomLevel
+	default:
+		return atomLevel
+	}
+}
+
+func isNumber(expr expression.Expression) bool {
+	switch expr.(type) {
+	case *expression.IntLiteral, *expression.FloatLiteral:
+		return true
+	default:
+		return false
+	}
+}
+
+// isField return true if field is scanned as single word with the same text
+// such as b or in, but not In which is scanned as in
+func isField(field string) bool {
+	s := scanner.NewScanner(strings.NewReader(field))
+
+	tokenText := s.Scan()
+	if !tokenText.Token.IsWord() || tokenText.Text != field {
+		return false
+	}
+
+	return s.Scan().Token == token.EOF
+}
+
+// isToken return true if input is scanned as single token with text
+func isToken(input string, expected token.Token, text string) bool {
+	s := scanner.NewScanner(strings.NewReader(input))
+
+	tokenText := s.Scan()
+	if tokenText.Token != expected || tokenText.Text != text {
+		return false
+	}
+
+	return s.Scan().Token == token.EOF
+}
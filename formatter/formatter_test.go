@@ -0,0 +1,301 @@
+package formatter
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/parser"
+	"github.com/haunt98/evaluator/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "literal",
+			input: `[true, 1, 1.5, 2.0, "a", NULL, $x]`,
+			want:  `[true, 1, 1.5, 2.0, "a", null, $x]`,
+		},
+		{
+			name:  "keyword",
+			input: "$a OR $b AND $c IN [1] or $d NOTIN [2]",
+			want:  "$a or $b and $c in [1] or $d notin [2]",
+		},
+		{
+			name:  "redundant parentheses",
+			input: "(($a + $b)) + ($c * $d)",
+			want:  "$a + $b + $c * $d",
+		},
+		{
+			name:  "lower precedence",
+			input: "($a or $b) and $c",
+			want:  "($a or $b) and $c",
+		},
+		{
+			name:  "right with the same precedence",
+			input: "$a - ($b - $c)",
+			want:  "$a - ($b - $c)",
+		},
+		{
+			name:  "not is left",
+			input: "(!$a) and $b",
+			want:  "(!$a) and $b",
+		},
+		{
+			name:  "not is right",
+			input: "$a and (!$b)",
+			want:  "$a and !$b",
+		},
+		{
+			name:  "not is not rightmost",
+			input: "($a and (!$b)) or $c",
+			want:  "$a and (!$b) or $c",
+		},
+		{
+			name:  "not takes all",
+			input: "!($a or $b)",
+			want:  "!$a or $b",
+		},
+		{
+			name:  "minus binary",
+			input: "-($a + 1)",
+			want:  "-($a + 1)",
+		},
+		{
+			name:  "minus number",
+			input: "-(1) - -1",
+			want:  "-(1) - -1",
+		},
+		{
+			name:  "minus member",
+			input: "-$a.b[0]",
+			want:  "-$a.b[0]",
+		},
+		{
+			name:  "keyword field",
+			input: "$a.in in $b.OR",
+			want:  "$a.in in $b.or",
+		},
+		{
+			name:  "object",
+			input: "($a + $b).c + (-$d)[0] + (1).e",
+			want:  "($a + $b).c + (-$d)[0] + (1).e",
+		},
+		{
+			name:  "call",
+			input: "len( [ 1 ,2 ] , $a[ 1 + 2 ] )",
+			want:  "len([1, 2], $a[1 + 2])",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expr, err := parser.NewParser(tc.input).Parse()
+			assert.NoError(t, err)
+
+			got, gotErr := Format(expr)
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFormatConstructed(t *testing.T) {
+	tests := []struct {
+		name string
+		expr expression.Expression
+		want string
+	}{
+		{
+			name: "float without fraction",
+			expr: expression.NewFloatLiteral(1e21),
+			want: "1e+21",
+		},
+		{
+			name: "negative zero",
+			expr: expression.NewFloatLiteral(math.Copysign(0, -1)),
+			want: "-0.0",
+		},
+		{
+			name: "min int",
+			expr: expression.NewIntLiteral(math.MinInt64),
+			want: "-9223372036854775808",
+		},
+		{
+			name: "minus negative int",
+			expr: expression.NewUnaryExpression(token.Minus, expression.NewIntLiteral(-1)),
+			want: "-(-1)",
+		},
+		{
+			name: "string with quote",
+			expr: expression.NewStringLiteral(`a"b`),
+			want: `"a\"b"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gotErr := Format(tc.expr)
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFormatFailed(t *testing.T) {
+	tests := []struct {
+		name string
+		expr expression.Expression
+	}{
+		{
+			name: "bad",
+			expr: expression.NewBadExpression(errors.New("bad")),
+		},
+		{
+			name: "NaN",
+			expr: expression.NewFloatLiteral(math.NaN()),
+		},
+		{
+			name: "infinity",
+			expr: expression.NewFloatLiteral(math.Inf(1)),
+		},
+		{
+			name: "var name",
+			expr: expression.NewVarExpression("a b"),
+		},
+		{
+			name: "upper keyword field",
+			expr: expression.NewMemberExpression(expression.NewVarExpression("a"), "In"),
+		},
+		{
+			name: "function name",
+			expr: expression.NewCallExpression("true"),
+		},
+		{
+			name: "binary operator",
+			expr: expression.NewBinaryExpression(token.Not, expression.NewBoolLiteral(true), expression.NewBoolLiteral(true)),
+		},
+		{
+			name: "nested",
+			expr: expression.NewArrayExpression(expression.NewBadExpression(errors.New("bad"))),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, gotErr := Format(tc.expr)
+			assert.Error(t, gotErr)
+		})
+	}
+}
+
+// randomExpr is random expression which is not necessarily valid to evaluate
+type randomExpr struct {
+	expr expression.Expression
+}
+
+func (randomExpr) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(randomExpr{
+		expr: randomExpression(r, r.Intn(5)+1),
+	})
+}
+
+func randomExpression(r *rand.Rand, depth int) expression.Expression {
+	choice := r.Intn(12)
+	if depth <= 0 {
+		choice = r.Intn(6)
+	}
+
+	switch choice {
+	case 0:
+		return expression.NewBoolLiteral(r.Intn(2) == 0)
+	case 1:
+		return expression.NewIntLiteral(int64(r.Intn(7) - 3))
+	case 2:
+		return expression.NewFloatLiteral(float64(r.Intn(7)-3) / 2)
+	case 3:
+		return expression.NewStringLiteral(randomString(r, "", "a", "b c", `d"e`, "f\ng", `h\i`, "é", "\x00", "\xff"))
+	case 4:
+		return expression.NewVarExpression(randomString(r, "a", "b", "_c1"))
+	case 5:
+		return expression.NewNullLiteral()
+	case 6:
+		return expression.NewUnaryExpression(randomToken(r, token.Not, token.Minus), randomExpression(r, depth-1))
+	case 7, 8:
+		operator := randomToken(r,
+			token.Or,
+			token.And,
+			token.Equal,
+			token.NotEqual,
+			token.Less,
+			token.LessOrEqual,
+			token.Greater,
+			token.GreaterOrEqual,
+			token.In,
+			token.NotIn,
+			token.Plus,
+			token.Minus,
+			token.Multiply,
+			token.Divide,
+			token.Modulo,
+		)
+
+		return expression.NewBinaryExpression(operator, randomExpression(r, depth-1), randomExpression(r, depth-1))
+	case 9:
+		return expression.NewArrayExpression(randomList(r, depth-1)...)
+	case 10:
+		return expression.NewCallExpression(randomString(r, "len", "f"), randomList(r, depth-1)...)
+	default:
+		if r.Intn(2) == 0 {
+			return expression.NewMemberExpression(randomExpression(r, depth-1), randomString(r, "a", "b", "in", "null"))
+		}
+
+		return expression.NewIndexExpression(randomExpression(r, depth-1), randomExpression(r, depth-1))
+	}
+}
+
+func randomList(r *rand.Rand, depth int) []expression.Expression {
+	exprs := make([]expression.Expression, r.Intn(3))
+	for i := range exprs {
+		exprs[i] = randomExpression(r, depth)
+	}
+
+	return exprs
+}
+
+func randomToken(r *rand.Rand, values ...token.Token) token.Token {
+	return values[r.Intn(len(values))]
+}
+
+func randomString(r *rand.Rand, values ...string) string {
+	return values[r.Intn(len(values))]
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	f := func(tc randomExpr) bool {
+		input, err := Format(tc.expr)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+
+		got, err := parser.NewParser(input).Parse()
+		if err != nil {
+			t.Logf("expr %s input %s: %v", tc.expr, input, err)
+			return false
+		}
+
+		return assert.Equal(t, tc.expr, got, input)
+	}
+
+	assert.NoError(t, quick.Check(f, &quick.Config{MaxCount: 1000}))
+}
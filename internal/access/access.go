@@ -0,0 +1,214 @@
+// Package access get field and index of go value by reflect
+// It is shared by evaluate, vm and schema so they agree on which fields exist
+// and return the same errors
+package access
+
+import (
+	"reflect"
+
+	"github.com/haunt98/evaluator/expression"
+)
+
+// tagName is struct tag to rename field
+// `evaluator:"name"` or `evaluator:"-"` to ignore field
+const tagName = "evaluator"
+
+// Member return field of map or struct, pointer is dereferenced
+// expr is only used to return error
+func Member(expr *expression.MemberExpression, object interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(object)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, newInvalidAccessError(expr, "nil")
+		}
+
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() == reflect.Invalid {
+		return nil, newInvalidAccessError(expr, "nil")
+	}
+
+	if !HasFields(rv) {
+		return nil, newInvalidAccessError(expr, rv.Type().String())
+	}
+
+	value, ok := Field(rv, expr.Field)
+	if !ok {
+		return nil, &MissingFieldError{
+			Expr: expr,
+			Path: path(expr),
+		}
+	}
+
+	return value.Interface(), nil
+}
+
+func newInvalidAccessError(expr expression.Expression, typ string) *InvalidAccessError {
+	return &InvalidAccessError{
+		Expr: expr,
+		Path: path(expr),
+		Type: typ,
+	}
+}
+
+// HasFields return true if rv is map with string key or struct
+// which can be accessed by Field
+func HasFields(rv reflect.Value) bool {
+	return (rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String) || rv.Kind() == reflect.Struct
+}
+
+// Field return field of map or struct, see HasFields
+// Struct field is exported field found by tag, or by name if tag is missing
+func Field(rv reflect.Value, field string) (reflect.Value, bool) {
+	if rv.Kind() == reflect.Map {
+		value := rv.MapIndex(reflect.ValueOf(field).Convert(rv.Type().Key()))
+		return value, value.IsValid()
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if sf.PkgPath != "" {
+			// unexported
+			continue
+		}
+
+		name := sf.Name
+		if tag, ok := sf.Tag.Lookup(tagName); ok {
+			if tag == "-" {
+				continue
+			}
+
+			if tag != "" {
+				name = tag
+			}
+		}
+
+		if name == field {
+			return rv.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// Index return item of slice, array by int index or item of map by key
+// or field of struct by string key, see Field
+// pointer is dereferenced, expr is only used to return error
+func Index(expr *expression.IndexExpression, object interface{}, indexExpr expression.Expression) (interface{}, error) {
+	rv := reflect.ValueOf(object)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, newInvalidAccessError(expr, "nil")
+		}
+
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return nil, newInvalidAccessError(expr, "nil")
+	case reflect.Slice, reflect.Array:
+		indexLit, ok := indexExpr.(*expression.IntLiteral)
+		if !ok {
+			return nil, &InvalidIndexError{
+				Expr:  expr,
+				Path:  path(expr),
+				Index: indexExpr,
+				Type:  rv.Type().String(),
+			}
+		}
+
+		if indexLit.Value < 0 || indexLit.Value >= int64(rv.Len()) {
+			return nil, &IndexOutOfRangeError{
+				Expr:   expr,
+				Path:   path(expr),
+				Index:  indexLit.Value,
+				Length: rv.Len(),
+			}
+		}
+
+		return rv.Index(int(indexLit.Value)).Interface(), nil
+	case reflect.Map:
+		key, ok := mapKey(rv.Type().Key(), indexExpr)
+		if !ok {
+			return nil, &InvalidIndexError{
+				Expr:  expr,
+				Path:  path(expr),
+				Index: indexExpr,
+				Type:  rv.Type().String(),
+			}
+		}
+
+		value := rv.MapIndex(key)
+		if !value.IsValid() {
+			return nil, &MissingKeyError{
+				Expr: expr,
+				Path: path(expr),
+				Key:  indexExpr,
+			}
+		}
+
+		return value.Interface(), nil
+	case reflect.Struct:
+		// the same as member so index agree with typecheck of object
+		keyLit, ok := indexExpr.(*expression.StringLiteral)
+		if !ok {
+			return nil, &InvalidIndexError{
+				Expr:  expr,
+				Path:  path(expr),
+				Index: indexExpr,
+				Type:  rv.Type().String(),
+			}
+		}
+
+		value, ok := Field(rv, keyLit.Value)
+		if !ok {
+			return nil, &MissingKeyError{
+				Expr: expr,
+				Path: path(expr),
+				Key:  indexExpr,
+			}
+		}
+
+		return value.Interface(), nil
+	default:
+		return nil, newInvalidAccessError(expr, rv.Type().String())
+	}
+}
+
+// mapKey convert literal to key type of map
+// only string, int and bool literal can be key
+func mapKey(keyType reflect.Type, indexExpr expression.Expression) (reflect.Value, bool) {
+	switch indexLit := indexExpr.(type) {
+	case *expression.StringLiteral:
+		if keyType.Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+
+		return reflect.ValueOf(indexLit.Value).Convert(keyType), true
+	case *expression.IntLiteral:
+		switch keyType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			key := reflect.New(keyType).Elem()
+			key.SetInt(indexLit.Value)
+			if key.Int() != indexLit.Value {
+				// overflow
+				return reflect.Value{}, false
+			}
+
+			return key, true
+		default:
+			return reflect.Value{}, false
+		}
+	case *expression.BoolLiteral:
+		if keyType.Kind() != reflect.Bool {
+			return reflect.Value{}, false
+		}
+
+		return reflect.ValueOf(indexLit.Value).Convert(keyType), true
+	default:
+		return reflect.Value{}, false
+	}
+}
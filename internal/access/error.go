@@ -0,0 +1,87 @@
+package access
+
+import (
+	"fmt"
+
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/formatter"
+)
+
+var (
+	_ error = (*MissingFieldError)(nil)
+	_ error = (*InvalidAccessError)(nil)
+	_ error = (*InvalidIndexError)(nil)
+	_ error = (*IndexOutOfRangeError)(nil)
+	_ error = (*MissingKeyError)(nil)
+)
+
+// MissingFieldError is returned when map or struct does not have field
+// Path is expression in source form such as $user.profile.country
+type MissingFieldError struct {
+	Expr *expression.MemberExpression
+	Path string
+}
+
+func (e *MissingFieldError) Error() string {
+	return "missing field " + e.Path
+}
+
+// InvalidAccessError is returned when value can not be accessed by field or index
+// such as field of int or field of nil
+// Type is go type of value, it is "nil" if value is nil
+type InvalidAccessError struct {
+	Expr expression.Expression
+	Path string
+	Type string
+}
+
+func (e *InvalidAccessError) Error() string {
+	return fmt.Sprintf("can not access %s of %s", e.Path, e.Type)
+}
+
+// InvalidIndexError is returned when index is not int of slice or not key type of map
+// Index is value of index, Type is go type of value which is accessed
+type InvalidIndexError struct {
+	Expr  *expression.IndexExpression
+	Path  string
+	Index expression.Expression
+	Type  string
+}
+
+func (e *InvalidIndexError) Error() string {
+	return fmt.Sprintf("can not use %s as index of %s in %s", e.Index, e.Type, e.Path)
+}
+
+// IndexOutOfRangeError is returned when index is negative or not less than length of slice
+type IndexOutOfRangeError struct {
+	Expr   *expression.IndexExpression
+	Path   string
+	Index  int64
+	Length int
+}
+
+func (e *IndexOutOfRangeError) Error() string {
+	return fmt.Sprintf("index %d out of range length %d in %s", e.Index, e.Length, e.Path)
+}
+
+// MissingKeyError is returned when map does not have key
+// Key is value of index
+type MissingKeyError struct {
+	Expr *expression.IndexExpression
+	Path string
+	Key  expression.Expression
+}
+
+func (e *MissingKeyError) Error() string {
+	return fmt.Sprintf("missing key %s in %s", e.Key, e.Path)
+}
+
+// path return expression in source form, it is only used in error
+func path(expr expression.Expression) string {
+	text, err := formatter.Format(expr)
+	if err != nil {
+		return expr.String()
+	}
+
+	return text
+}
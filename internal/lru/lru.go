@@ -0,0 +1,131 @@
+// Package lru is least recently used cache with optional time to live
+// It is shared by cache and builtin so both are bounded the same way
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache is safe for concurrent use
+type Cache struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	// mu guard ll, items and stats
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+	stats Stats
+}
+
+type entry struct {
+	key       string
+	value     interface{}
+	expiredAt time.Time
+}
+
+// Stats is counters of cache
+// Evictions count entries which are removed because cache is full or entry is expired
+type Stats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+}
+
+// New return cache which keep at most size entries, size <= 0 means unbounded
+// ttl <= 0 means no expiration, now is used to check expiration
+func New(size int, ttl time.Duration, now func() time.Time) *Cache {
+	return &Cache{
+		size:  size,
+		ttl:   ttl,
+		now:   now,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// Get return value of key and mark it as recently used
+func (c *Cache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+
+	e := elem.Value.(*entry)
+	if c.isExpired(e) {
+		c.remove(elem)
+		c.stats.Misses++
+		return nil, false
+	}
+
+	c.ll.MoveToFront(elem)
+	c.stats.Hits++
+
+	return e.value, true
+}
+
+// Add store value of key and return stored value
+// If key is already stored and not expired, stored value is kept
+// so concurrent callers which compute the same key get the same value
+func (c *Cache) Add(key string, value interface{}) interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		e := elem.Value.(*entry)
+		if !c.isExpired(e) {
+			c.ll.MoveToFront(elem)
+			return e.value
+		}
+
+		c.remove(elem)
+	}
+
+	e := &entry{
+		key:   key,
+		value: value,
+	}
+	if c.ttl > 0 {
+		e.expiredAt = c.now().Add(c.ttl)
+	}
+
+	c.items[key] = c.ll.PushFront(e)
+
+	for c.size > 0 && c.ll.Len() > c.size {
+		c.remove(c.ll.Back())
+	}
+
+	return value
+}
+
+func (c *Cache) isExpired(e *entry) bool {
+	return c.ttl > 0 && !c.now().Before(e.expiredAt)
+}
+
+func (c *Cache) remove(elem *list.Element) {
+	c.ll.Remove(elem)
+	delete(c.items, elem.Value.(*entry).key)
+	c.stats.Evictions++
+}
+
+// Len return number of entries, expired entries are counted until they are removed
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
+
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats
+}
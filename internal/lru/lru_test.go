@@ -0,0 +1,71 @@
+package lru
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheBounded(t *testing.T) {
+	c := New(2, 0, time.Now)
+
+	for i := 0; i < 10; i++ {
+		c.Add(fmt.Sprintf("a%d", i), i)
+	}
+
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, int64(8), c.Stats().Evictions)
+}
+
+func TestCacheRecentlyUsed(t *testing.T) {
+	c := New(2, 0, time.Now)
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+
+	// a is used so b is evicted
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+	c.Add("c", 3)
+
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+
+	got, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, got)
+}
+
+func TestCacheAddKeepStored(t *testing.T) {
+	c := New(2, 0, time.Now)
+
+	assert.Equal(t, 1, c.Add("a", 1))
+	assert.Equal(t, 1, c.Add("a", 2))
+}
+
+func TestCacheTTL(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c := New(0, time.Minute, func() time.Time {
+		return now
+	})
+
+	c.Add("a", 1)
+
+	now = now.Add(59 * time.Second)
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, Stats{
+		Hits:      1,
+		Misses:    1,
+		Evictions: 1,
+	}, c.Stats())
+}
@@ -0,0 +1,137 @@
+// Package number compare and calculate int64, float64
+// It is shared by evaluate and vm so they return the same result and error
+package number
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/haunt98/evaluator/token"
+)
+
+var (
+	// ErrDivisionByZero is converted to evaluate.DivisionByZeroError with operands
+	ErrDivisionByZero = errors.New("division by zero")
+	// ErrOverflow is converted to evaluate.OverflowError with operands
+	ErrOverflow = errors.New("int overflow")
+)
+
+func CompareInt(op token.Token, left, right int64) (bool, error) {
+	switch op {
+	case token.Equal:
+		return left == right, nil
+	case token.Less:
+		return left < right, nil
+	case token.LessOrEqual:
+		return left <= right, nil
+	case token.Greater:
+		return left > right, nil
+	case token.GreaterOrEqual:
+		return left >= right, nil
+	default:
+		return false, fmt.Errorf("not implement compare operator %s", op)
+	}
+}
+
+func CompareFloat(op token.Token, left, right float64) (bool, error) {
+	switch op {
+	case token.Equal:
+		return left == right, nil
+	case token.Less:
+		return left < right, nil
+	case token.LessOrEqual:
+		return left <= right, nil
+	case token.Greater:
+		return left > right, nil
+	case token.GreaterOrEqual:
+		return left >= right, nil
+	default:
+		return false, fmt.Errorf("not implement compare operator %s", op)
+	}
+}
+
+// ArithmeticInt return error if overflow
+func ArithmeticInt(op token.Token, left, right int64) (int64, error) {
+	switch op {
+	case token.Plus:
+		if (right > 0 && left > math.MaxInt64-right) ||
+			(right < 0 && left < math.MinInt64-right) {
+			return 0, ErrOverflow
+		}
+
+		return left + right, nil
+	case token.Minus:
+		if (right < 0 && left > math.MaxInt64+right) ||
+			(right > 0 && left < math.MinInt64+right) {
+			return 0, ErrOverflow
+		}
+
+		return left - right, nil
+	case token.Multiply:
+		if left == 0 || right == 0 {
+			return 0, nil
+		}
+
+		result := left * right
+		if result/right != left ||
+			(left == -1 && right == math.MinInt64) ||
+			(right == -1 && left == math.MinInt64) {
+			return 0, ErrOverflow
+		}
+
+		return result, nil
+	case token.Divide:
+		if right == 0 {
+			return 0, ErrDivisionByZero
+		}
+
+		if left == math.MinInt64 && right == -1 {
+			return 0, ErrOverflow
+		}
+
+		return left / right, nil
+	case token.Modulo:
+		if right == 0 {
+			return 0, ErrDivisionByZero
+		}
+
+		return left % right, nil
+	default:
+		return 0, fmt.Errorf("not implement arithmetic operator %s", op)
+	}
+}
+
+func ArithmeticFloat(op token.Token, left, right float64) (float64, error) {
+	switch op {
+	case token.Plus:
+		return left + right, nil
+	case token.Minus:
+		return left - right, nil
+	case token.Multiply:
+		return left * right, nil
+	case token.Divide:
+		if right == 0 {
+			return 0, ErrDivisionByZero
+		}
+
+		return left / right, nil
+	case token.Modulo:
+		if right == 0 {
+			return 0, ErrDivisionByZero
+		}
+
+		return math.Mod(left, right), nil
+	default:
+		return 0, fmt.Errorf("not implement arithmetic operator %s", op)
+	}
+}
+
+// Negate return error if overflow
+func Negate(value int64) (int64, error) {
+	if value == math.MinInt64 {
+		return 0, ErrOverflow
+	}
+
+	return -value, nil
+}
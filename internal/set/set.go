@@ -0,0 +1,88 @@
+// Package set is hash set of literals which is right of in, notin
+// It is shared by evaluate and vm so both keep semantics of equal:
+// int and float are compared as float64, values with different types are not equal
+package set
+
+// MinSize is min number of values to use set instead of comparing each value
+const MinSize = 8
+
+// Set is not safe for concurrent add, it is safe for concurrent contains after all adds
+type Set struct {
+	hasFalse bool
+	hasTrue  bool
+	hasNull  bool
+	ints     map[int64]struct{}
+	floats   map[float64]struct{}
+	// intsAsFloat is ints which are compared with float
+	intsAsFloat map[float64]struct{}
+	strings     map[string]struct{}
+}
+
+func New() *Set {
+	return &Set{
+		ints:        make(map[int64]struct{}),
+		floats:      make(map[float64]struct{}),
+		intsAsFloat: make(map[float64]struct{}),
+		strings:     make(map[string]struct{}),
+	}
+}
+
+func (s *Set) AddBool(v bool) {
+	if v {
+		s.hasTrue = true
+	} else {
+		s.hasFalse = true
+	}
+}
+
+func (s *Set) AddInt(v int64) {
+	s.ints[v] = struct{}{}
+	s.intsAsFloat[float64(v)] = struct{}{}
+}
+
+func (s *Set) AddFloat(v float64) {
+	s.floats[v] = struct{}{}
+}
+
+func (s *Set) AddString(v string) {
+	s.strings[v] = struct{}{}
+}
+
+func (s *Set) AddNull() {
+	s.hasNull = true
+}
+
+func (s *Set) ContainsBool(v bool) bool {
+	if v {
+		return s.hasTrue
+	}
+
+	return s.hasFalse
+}
+
+func (s *Set) ContainsInt(v int64) bool {
+	if _, ok := s.ints[v]; ok {
+		return true
+	}
+
+	_, ok := s.floats[float64(v)]
+	return ok
+}
+
+func (s *Set) ContainsFloat(v float64) bool {
+	if _, ok := s.floats[v]; ok {
+		return true
+	}
+
+	_, ok := s.intsAsFloat[v]
+	return ok
+}
+
+func (s *Set) ContainsString(v string) bool {
+	_, ok := s.strings[v]
+	return ok
+}
+
+func (s *Set) ContainsNull() bool {
+	return s.hasNull
+}
@@ -0,0 +1,34 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet(t *testing.T) {
+	s := New()
+	s.AddBool(true)
+	s.AddInt(1)
+	s.AddFloat(2.5)
+	s.AddFloat(3)
+	s.AddString("a")
+
+	assert.True(t, s.ContainsBool(true))
+	assert.False(t, s.ContainsBool(false))
+
+	// int and float are compared as float64
+	assert.True(t, s.ContainsInt(1))
+	assert.True(t, s.ContainsInt(3))
+	assert.False(t, s.ContainsInt(2))
+	assert.True(t, s.ContainsFloat(1))
+	assert.True(t, s.ContainsFloat(2.5))
+	assert.False(t, s.ContainsFloat(1.5))
+
+	assert.True(t, s.ContainsString("a"))
+	assert.False(t, s.ContainsString("1"))
+
+	assert.False(t, s.ContainsNull())
+	s.AddNull()
+	assert.True(t, s.ContainsNull())
+}
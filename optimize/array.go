@@ -0,0 +1,80 @@
+package optimize
+
+import (
+	"sort"
+
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/internal/set"
+)
+
+// constantIn return set of constant array which is right of in, notin if it has many children
+// so evaluate does not compare each child, otherwise return sorted array
+func constantIn(expr *expression.ArrayExpression) expression.Expression {
+	if len(expr.Children) >= set.MinSize {
+		return expression.NewSetLiteral(expr.Children...)
+	}
+
+	return sortArray(expr)
+}
+
+// sortArray return sorted copy of constant array, or array itself if it is sorted
+// Only used for right of in, notin because order of children does not change result
+// bool < number < string < others, int and float are compared as float64
+func sortArray(expr *expression.ArrayExpression) *expression.ArrayExpression {
+	if sort.SliceIsSorted(expr.Children, func(i, j int) bool {
+		return less(expr.Children[i], expr.Children[j])
+	}) {
+		return expr
+	}
+
+	children := make([]expression.Expression, len(expr.Children))
+	copy(children, expr.Children)
+
+	sort.SliceStable(children, func(i, j int) bool {
+		return less(children[i], children[j])
+	})
+
+	return expression.NewArrayExpression(children...)
+}
+
+func less(left, right expression.Expression) bool {
+	leftRank, rightRank := rank(left), rank(right)
+	if leftRank != rightRank {
+		return leftRank < rightRank
+	}
+
+	switch l := left.(type) {
+	case *expression.BoolLiteral:
+		return !l.Value && right.(*expression.BoolLiteral).Value
+	case *expression.StringLiteral:
+		return l.Value < right.(*expression.StringLiteral).Value
+	case *expression.IntLiteral, *expression.FloatLiteral:
+		return number(left) < number(right)
+	default:
+		return false
+	}
+}
+
+func rank(expr expression.Expression) int {
+	switch expr.(type) {
+	case *expression.BoolLiteral:
+		return 0
+	case *expression.IntLiteral, *expression.FloatLiteral:
+		return 1
+	case *expression.StringLiteral:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func number(expr expression.Expression) float64 {
+	switch e := expr.(type) {
+	case *expression.IntLiteral:
+		return float64(e.Value)
+	case *expression.FloatLiteral:
+		return e.Value
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,93 @@
+package optimize
+
+import (
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/token"
+)
+
+// isBool return true if expr is always evaluated to bool or error
+// so and, or, ! with expr as operand does not need to check type of expr
+func isBool(expr expression.Expression) bool {
+	switch e := expr.(type) {
+	case *expression.BoolLiteral:
+		return true
+	case *expression.UnaryExpression:
+		return e.Operator == token.Not
+	case *expression.BinaryExpression:
+		switch e.Operator {
+		case token.Or,
+			token.And,
+			token.Equal,
+			token.NotEqual,
+			token.Less,
+			token.LessOrEqual,
+			token.Greater,
+			token.GreaterOrEqual,
+			token.In,
+			token.NotIn:
+			return true
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+}
+
+// false and any -> false
+// true and x -> x if x is bool
+// x and true -> x if x is bool
+// x and false is kept because x may fail
+func simplifyAnd(expr *expression.BinaryExpression) expression.Expression {
+	if leftLit, ok := expr.Left.(*expression.BoolLiteral); ok {
+		if !leftLit.Value {
+			return leftLit
+		}
+
+		if isBool(expr.Right) {
+			return expr.Right
+		}
+
+		return expr
+	}
+
+	if rightLit, ok := expr.Right.(*expression.BoolLiteral); ok && rightLit.Value && isBool(expr.Left) {
+		return expr.Left
+	}
+
+	return expr
+}
+
+// true or any -> true
+// false or x -> x if x is bool
+// x or false -> x if x is bool
+// x or true is kept because x may fail
+func simplifyOr(expr *expression.BinaryExpression) expression.Expression {
+	if leftLit, ok := expr.Left.(*expression.BoolLiteral); ok {
+		if leftLit.Value {
+			return leftLit
+		}
+
+		if isBool(expr.Right) {
+			return expr.Right
+		}
+
+		return expr
+	}
+
+	if rightLit, ok := expr.Right.(*expression.BoolLiteral); ok && !rightLit.Value && isBool(expr.Left) {
+		return expr.Left
+	}
+
+	return expr
+}
+
+// !!x -> x if x is bool
+func simplifyNot(expr *expression.UnaryExpression) expression.Expression {
+	child, ok := expr.Child.(*expression.UnaryExpression)
+	if !ok || child.Operator != token.Not || !isBool(child.Child) {
+		return expr
+	}
+
+	return child.Child
+}
@@ -0,0 +1,40 @@
+package optimize
+
+import (
+	"github.com/haunt98/evaluator/evaluate"
+	"github.com/haunt98/evaluator/expression"
+)
+
+// isConstant return true if expr is literal, set or array of constants
+func isConstant(expr expression.Expression) bool {
+	switch e := expr.(type) {
+	case *expression.BoolLiteral,
+		*expression.IntLiteral,
+		*expression.FloatLiteral,
+		*expression.StringLiteral,
+		*expression.NullLiteral,
+		*expression.SetLiteral:
+		return true
+	case *expression.ArrayExpression:
+		for _, child := range e.Children {
+			if !isConstant(child) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
+// fold evaluate expr which only has constant operands
+// expr is kept if it fails so error is returned when evaluate
+func fold(expr expression.Expression) expression.Expression {
+	result, err := evaluate.NewVisitor(nil).Visit(expr)
+	if err != nil {
+		return expr
+	}
+
+	return result
+}
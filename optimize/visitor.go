@@ -0,0 +1,181 @@
+// Package optimize rewrite expression to smaller equivalent expression before evaluate
+// Optimized expression returns the same result as input expression,
+// and error of the same type and message
+// Unchanged subtrees are reused so their errors have the same Expr as input expression,
+// errors of rewritten expressions have Expr of rewritten ones
+package optimize
+
+import (
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/token"
+)
+
+var _ expression.Visitor = (*visitor)(nil)
+
+// visitor return optimized expression, input expression is not changed
+// expression is only copied if its children are changed
+type visitor struct{}
+
+func NewVisitor() *visitor {
+	return &visitor{}
+}
+
+// Optimize return optimized expression
+func Optimize(expr expression.Expression) (expression.Expression, error) {
+	return NewVisitor().Visit(expr)
+}
+
+func (v *visitor) Visit(expr expression.Expression) (expression.Expression, error) {
+	return expr.Accept(v)
+}
+
+func (v *visitor) VisitLiteral(expr expression.Expression) (expression.Expression, error) {
+	return expr, nil
+}
+
+func (v *visitor) VisitVar(expr *expression.VarExpression) (expression.Expression, error) {
+	return expr, nil
+}
+
+func (v *visitor) VisitArray(expr *expression.ArrayExpression) (expression.Expression, error) {
+	children, changed, err := v.visitChildren(expr.Children)
+	if err != nil {
+		return nil, err
+	}
+
+	if !changed {
+		return expr, nil
+	}
+
+	return expression.NewArrayExpression(children...), nil
+}
+
+func (v *visitor) VisitUnary(expr *expression.UnaryExpression) (expression.Expression, error) {
+	child, err := v.Visit(expr.Child)
+	if err != nil {
+		return nil, err
+	}
+
+	result := expr
+	if child != expr.Child {
+		result = expression.NewUnaryExpression(expr.Operator, child)
+	}
+
+	if isConstant(child) {
+		return fold(result), nil
+	}
+
+	if expr.Operator == token.Not {
+		return simplifyNot(result), nil
+	}
+
+	return result, nil
+}
+
+func (v *visitor) VisitBinary(expr *expression.BinaryExpression) (expression.Expression, error) {
+	left, err := v.Visit(expr.Left)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := v.Visit(expr.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	if expr.Operator == token.In || expr.Operator == token.NotIn {
+		if arr, ok := right.(*expression.ArrayExpression); ok && isConstant(arr) {
+			right = constantIn(arr)
+		}
+	}
+
+	result := expr
+	if left != expr.Left || right != expr.Right {
+		result = expression.NewBinaryExpression(expr.Operator, left, right)
+	}
+
+	if isConstant(left) && isConstant(right) {
+		return fold(result), nil
+	}
+
+	switch expr.Operator {
+	case token.And:
+		return simplifyAnd(result), nil
+	case token.Or:
+		return simplifyOr(result), nil
+	default:
+		return result, nil
+	}
+}
+
+// VisitCall does not fold call because function may return different result each call
+func (v *visitor) VisitCall(expr *expression.CallExpression) (expression.Expression, error) {
+	args, changed, err := v.visitChildren(expr.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	if !changed {
+		return expr, nil
+	}
+
+	return expression.NewCallExpression(expr.Name, args...), nil
+}
+
+func (v *visitor) VisitMember(expr *expression.MemberExpression) (expression.Expression, error) {
+	object, err := v.Visit(expr.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	result := expr
+	if object != expr.Object {
+		result = expression.NewMemberExpression(object, expr.Field)
+	}
+
+	if isConstant(object) {
+		return fold(result), nil
+	}
+
+	return result, nil
+}
+
+func (v *visitor) VisitIndex(expr *expression.IndexExpression) (expression.Expression, error) {
+	object, err := v.Visit(expr.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := v.Visit(expr.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	result := expr
+	if object != expr.Object || index != expr.Index {
+		result = expression.NewIndexExpression(object, index)
+	}
+
+	if isConstant(object) && isConstant(index) {
+		return fold(result), nil
+	}
+
+	return result, nil
+}
+
+// visitChildren return optimized children and true if any child is changed
+func (v *visitor) visitChildren(exprs []expression.Expression) ([]expression.Expression, bool, error) {
+	children := make([]expression.Expression, len(exprs))
+	changed := false
+	for i, child := range exprs {
+		result, err := v.Visit(child)
+		if err != nil {
+			return nil, false, err
+		}
+
+		children[i] = result
+		changed = changed || result != child
+	}
+
+	return children, changed, nil
+}
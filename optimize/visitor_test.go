@@ -0,0 +1,352 @@
+package optimize
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/haunt98/evaluator/evaluate"
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/parser"
+	"github.com/haunt98/evaluator/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptimize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "fold arithmetic",
+			input: "1 + 2 * 3",
+			want:  "7",
+		},
+		{
+			name:  "fold compare",
+			input: "1 < 2 or $y",
+			want:  "true",
+		},
+		{
+			name:  "true and",
+			input: "true and $x > 1",
+			want:  "Varx > 1",
+		},
+		{
+			name:  "and true",
+			input: "$x in [1] and true",
+			want:  "Varx In [1]",
+		},
+		{
+			name:  "true and var is kept",
+			input: "true and $x",
+			want:  "true And Varx",
+		},
+		{
+			name:  "var and true is kept",
+			input: "$x and true",
+			want:  "Varx And true",
+		},
+		{
+			name:  "false and",
+			input: "false and $x",
+			want:  "false",
+		},
+		{
+			name:  "and false is kept",
+			input: "$x == 1 and false",
+			want:  "Varx == 1 And false",
+		},
+		{
+			name:  "false or",
+			input: "false or !$x",
+			want:  "!Varx",
+		},
+		{
+			name:  "or false",
+			input: "($x and $y) or false",
+			want:  "Varx And Vary",
+		},
+		{
+			name:  "false or var is kept",
+			input: "false or $x",
+			want:  "false Or Varx",
+		},
+		{
+			name:  "var or false is kept",
+			input: "$x or false",
+			want:  "Varx Or false",
+		},
+		{
+			name:  "true or",
+			input: "true or $x",
+			want:  "true",
+		},
+		{
+			name:  "or true is kept",
+			input: "$x != 1 or true",
+			want:  "Varx != 1 Or true",
+		},
+		{
+			name:  "double not",
+			input: "!(!($x <= 1))",
+			want:  "Varx <= 1",
+		},
+		{
+			name:  "double not var is kept",
+			input: "!(!$x)",
+			want:  "!!Varx",
+		},
+		{
+			name:  "fold not",
+			input: "!(1 == 2)",
+			want:  "true",
+		},
+		{
+			name:  "sort array of in",
+			input: `$x in ["b", 3, true, 1.5, "a", 2]`,
+			want:  `Varx In [true ,1.5 ,2 ,3 ,"a" ,"b"]`,
+		},
+		{
+			name:  "set of in",
+			input: `$x notin [8, 7, 6, 5, 4, 3, 2, 1]`,
+			want:  `Varx NotIn Set[8 ,7 ,6 ,5 ,4 ,3 ,2 ,1]`,
+		},
+		{
+			name:  "array with var is not sorted",
+			input: "$x notin [2, $y, 1]",
+			want:  "Varx NotIn [2 ,Vary ,1]",
+		},
+		{
+			name:  "array outside in is not sorted",
+			input: "[2, 1] == $x",
+			want:  "[2 ,1] == Varx",
+		},
+		{
+			name:  "fold index",
+			input: "[1, 2][1] + $x",
+			want:  "2 + Varx",
+		},
+		{
+			name:  "failed fold is kept",
+			input: "1 / 0 == $x",
+			want:  "1 / 0 == Varx",
+		},
+		{
+			name:  "call is not folded",
+			input: "len(1 + 1)",
+			want:  "len(2)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expr, err := parser.NewParser(tc.input).Parse()
+			assert.NoError(t, err)
+
+			got, gotErr := Optimize(expr)
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tc.want, got.String())
+		})
+	}
+}
+
+func TestOptimizeNotChangeInput(t *testing.T) {
+	expr, err := parser.NewParser(`true and $x in [3, 2, 1]`).Parse()
+	assert.NoError(t, err)
+
+	want := expr.String()
+
+	_, gotErr := Optimize(expr)
+	assert.NoError(t, gotErr)
+	assert.Equal(t, want, expr.String())
+}
+
+func TestOptimizeReuseUnchanged(t *testing.T) {
+	expr, err := parser.NewParser(`$x.a[0] > 1 and len($y, [1, 2]) in [1, 2]`).Parse()
+	assert.NoError(t, err)
+
+	got, gotErr := Optimize(expr)
+	assert.NoError(t, gotErr)
+	assert.Same(t, expr, got)
+}
+
+func TestOptimizeErrorOfUnchanged(t *testing.T) {
+	// 1 + "a" fails to fold so it is kept as is
+	expr, err := parser.NewParser(`1 + 2 < 1 + "a"`).Parse()
+	assert.NoError(t, err)
+	failed := expr.(*expression.BinaryExpression).Right
+
+	got, gotErr := Optimize(expr)
+	assert.NoError(t, gotErr)
+	assert.NotSame(t, expr, got)
+
+	_, gotErr = evaluate.NewVisitor(nil).Visit(got)
+
+	var typeErr *evaluate.TypeMismatchError
+	assert.ErrorAs(t, gotErr, &typeErr)
+	assert.Same(t, failed, typeErr.Expr)
+}
+
+// randomCase is random bool expression with random args
+// Operands of and, or, ! may be not bool so evaluate may fail
+type randomCase struct {
+	expr expression.Expression
+	args map[string]interface{}
+}
+
+func (randomCase) Generate(r *rand.Rand, _ int) reflect.Value {
+	args := map[string]interface{}{
+		"a": r.Intn(2) == 0,
+		"b": r.Intn(2) == 0,
+		"x": r.Intn(7) - 3,
+		"y": r.Intn(7) - 3,
+		"s": randomString(r, "", "a"),
+	}
+
+	// missing var so evaluate may fail
+	if r.Intn(10) == 0 {
+		delete(args, "y")
+	}
+
+	depth := r.Intn(5) + 1
+
+	return reflect.ValueOf(randomCase{
+		expr: randomBool(r, depth),
+		args: args,
+	})
+}
+
+func randomBool(r *rand.Rand, depth int) expression.Expression {
+	choice := r.Intn(9)
+	if depth <= 0 {
+		choice = r.Intn(3)
+	}
+
+	switch choice {
+	case 0:
+		return expression.NewBoolLiteral(r.Intn(2) == 0)
+	case 1:
+		return expression.NewVarExpression(randomString(r, "a", "b"))
+	case 8:
+		return randomNotBool(r)
+	case 2:
+		return expression.NewUnaryExpression(token.Not, randomBool(r, depth-1))
+	case 3:
+		return expression.NewBinaryExpression(token.And, randomBool(r, depth-1), randomBool(r, depth-1))
+	case 4:
+		return expression.NewBinaryExpression(token.Or, randomBool(r, depth-1), randomBool(r, depth-1))
+	case 5:
+		operators := []token.Token{token.In, token.NotIn}
+		children := make([]expression.Expression, r.Intn(4))
+		for i := range children {
+			children[i] = randomNumber(r, 0)
+		}
+
+		return expression.NewBinaryExpression(operators[r.Intn(len(operators))],
+			randomNumber(r, depth-1), expression.NewArrayExpression(children...))
+	default:
+		operators := []token.Token{
+			token.Equal,
+			token.NotEqual,
+			token.Less,
+			token.LessOrEqual,
+			token.Greater,
+			token.GreaterOrEqual,
+		}
+
+		return expression.NewBinaryExpression(operators[r.Intn(len(operators))],
+			randomNumber(r, depth-1), randomNumber(r, depth-1))
+	}
+}
+
+func randomNumber(r *rand.Rand, depth int) expression.Expression {
+	choice := r.Intn(5)
+	if depth <= 0 {
+		choice = r.Intn(3)
+	}
+
+	switch choice {
+	case 0:
+		return expression.NewIntLiteral(int64(r.Intn(7) - 3))
+	case 1:
+		if r.Intn(2) == 0 {
+			return expression.NewFloatLiteral(float64(r.Intn(7)-3) / 2)
+		}
+
+		return expression.NewVarExpression(randomString(r, "x", "y"))
+	case 2:
+		return expression.NewVarExpression(randomString(r, "x", "y"))
+	case 3:
+		return expression.NewUnaryExpression(token.Minus, randomNumber(r, depth-1))
+	default:
+		operators := []token.Token{
+			token.Plus,
+			token.Minus,
+			token.Multiply,
+			token.Divide,
+			token.Modulo,
+		}
+
+		return expression.NewBinaryExpression(operators[r.Intn(len(operators))],
+			randomNumber(r, depth-1), randomNumber(r, depth-1))
+	}
+}
+
+// randomNotBool is operand of and, or, ! which is not bool
+func randomNotBool(r *rand.Rand) expression.Expression {
+	switch r.Intn(4) {
+	case 0:
+		return expression.NewVarExpression(randomString(r, "s", "x"))
+	case 1:
+		return expression.NewStringLiteral(randomString(r, "", "a"))
+	case 2:
+		return expression.NewIntLiteral(int64(r.Intn(3)))
+	default:
+		return expression.NewUnaryExpression(token.Minus, expression.NewVarExpression("x"))
+	}
+}
+
+func randomString(r *rand.Rand, values ...string) string {
+	return values[r.Intn(len(values))]
+}
+
+func TestOptimizeEquivalent(t *testing.T) {
+	f := func(tc randomCase) bool {
+		wantResult, wantErr := evaluate.NewVisitor(tc.args).Visit(tc.expr)
+
+		optimizedExpr, err := Optimize(tc.expr)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+
+		gotResult, gotErr := evaluate.NewVisitor(tc.args).Visit(optimizedExpr)
+		if (wantErr != nil) != (gotErr != nil) {
+			t.Logf("expr %s optimized %s args %v: expect error %v got %v", tc.expr, optimizedExpr, tc.args, wantErr, gotErr)
+			return false
+		}
+
+		if wantErr != nil {
+			if wantErr.Error() != gotErr.Error() {
+				t.Logf("expr %s optimized %s args %v: expect error %v got %v", tc.expr, optimizedExpr, tc.args, wantErr, gotErr)
+				return false
+			}
+
+			return true
+		}
+
+		if !reflect.DeepEqual(wantResult, gotResult) {
+			t.Logf("expr %s optimized %s args %v: expect %s got %s", tc.expr, optimizedExpr, tc.args, wantResult, gotResult)
+			return false
+		}
+
+		return true
+	}
+
+	assert.NoError(t, quick.Check(f, &quick.Config{
+		MaxCount: 5000,
+	}))
+}
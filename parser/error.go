@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/haunt98/evaluator/scanner"
+	"github.com/haunt98/evaluator/token"
+)
+
+var _ error = (*ParseError)(nil)
+
+// ParseError is error at found token
+// Offset start from 0, Line and Column start from 1
+// Expected is tokens which parser want instead of found token
+type ParseError struct {
+	Offset   int
+	Line     int
+	Column   int
+	Found    scanner.TokenText
+	Expected []token.Token
+	Msg      string
+	Err      error
+}
+
+// newExpectError return error when found token is not one of expected tokens
+func newExpectError(found scanner.TokenText, expected ...token.Token) *ParseError {
+	return &ParseError{
+		Offset:   found.Pos.Offset,
+		Line:     found.Pos.Line,
+		Column:   found.Pos.Column,
+		Found:    found,
+		Expected: expected,
+	}
+}
+
+func newParseError(found scanner.TokenText, msg string, err error) *ParseError {
+	return &ParseError{
+		Offset: found.Pos.Offset,
+		Line:   found.Pos.Line,
+		Column: found.Pos.Column,
+		Found:  found,
+		Msg:    msg,
+		Err:    err,
+	}
+}
+
+// newUnexpectedError return error when found token should not be there
+// such as illegal token or token after complete expression
+func newUnexpectedError(found scanner.TokenText) *ParseError {
+	if found.Token == token.Illegal {
+		return newParseError(found, "illegal", found.Err)
+	}
+
+	return newParseError(found, "unexpected", nil)
+}
+
+func (e *ParseError) Error() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%d:%d: ", e.Line, e.Column)
+
+	if len(e.Expected) != 0 {
+		expectedRepresents := make([]string, len(e.Expected))
+		for i, expected := range e.Expected {
+			expectedRepresents[i] = expected.String()
+		}
+
+		fmt.Fprintf(&sb, "expect %s got %s", strings.Join(expectedRepresents, " or "), e.Found)
+	} else {
+		fmt.Fprintf(&sb, "%s %s", e.Msg, e.Found)
+	}
+
+	if e.Err != nil {
+		fmt.Fprintf(&sb, ": %s", e.Err)
+	}
+
+	return sb.String()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Snippet return error with line of input and caret under found token
+//
+//	1:8: expect ) got token EOF text
+//	$a == (1
+//	        ^
+func (e *ParseError) Snippet(input string) string {
+	lines := strings.Split(input, "\n")
+	if e.Line < 1 || e.Line > len(lines) {
+		return e.Error()
+	}
+
+	line := lines[e.Line-1]
+
+	var caret strings.Builder
+	column := 1
+	for _, r := range line {
+		if column >= e.Column {
+			break
+		}
+
+		// keep tab so caret is aligned with line
+		if r == '\t' {
+			caret.WriteRune('\t')
+		} else {
+			caret.WriteRune(' ')
+		}
+
+		column++
+	}
+	caret.WriteRune('^')
+
+	return e.Error() + "\n" + line + "\n" + caret.String()
+}
+
+// FormatError return snippet if err is parse error, otherwise return err as is
+func FormatError(input string, err error) string {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.Snippet(input)
+	}
+
+	return err.Error()
+}
+
+// ParseErrors is all syntax errors of input, see ParseAll
+type ParseErrors []*ParseError
+
+func (errs ParseErrors) Error() string {
+	represents := make([]string, len(errs))
+	for i, err := range errs {
+		represents[i] = err.Error()
+	}
+
+	return strings.Join(represents, "; ")
+}
@@ -1,16 +1,15 @@
 package parser
 
 import (
-	"fmt"
-
 	"github.com/haunt98/evaluator/expression"
 	"github.com/haunt98/evaluator/scanner"
+	"github.com/haunt98/evaluator/token"
 )
 
 func (p *Parser) led(tokenText scanner.TokenText, expr expression.Expression) (expression.Expression, error) {
 	fn, ok := p.ledFns[tokenText.Token]
 	if !ok {
-		return nil, fmt.Errorf("not implement left denotation token %s text %s", tokenText.Token, tokenText.Text)
+		return nil, newParseError(tokenText, "not implement left denotation", nil)
 	}
 
 	return fn(tokenText, expr)
@@ -24,3 +23,32 @@ func (p *Parser) ledInfix(tokenText scanner.TokenText, expr expression.Expressio
 
 	return expression.NewBinaryExpression(tokenText.Token, expr, rightExpr), nil
 }
+
+// $a.b -> member b of $a
+// $a.in -> member in of $a, keyword is field name after dot
+// keyword is case insensitive so its field name is lower case
+func (p *Parser) ledDot(_ scanner.TokenText, expr expression.Expression) (expression.Expression, error) {
+	field := p.bs.Peek()
+	if !field.Token.IsWord() {
+		// keep token so parser can recover from it
+		return nil, newExpectError(field, token.Ident)
+	}
+
+	p.bs.Scan()
+
+	return expression.NewMemberExpression(expr, field.Text), nil
+}
+
+// $a[0] -> index 0 of $a
+func (p *Parser) ledSquareBracket(_ scanner.TokenText, expr expression.Expression) (expression.Expression, error) {
+	index, err := p.parseWithPrecedence(token.LowestLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(token.CloseSquareBracket); err != nil {
+		return nil, err
+	}
+
+	return expression.NewIndexExpression(expr, index), nil
+}
@@ -1,8 +1,8 @@
 package parser
 
 import (
-	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/haunt98/evaluator/expression"
 	"github.com/haunt98/evaluator/scanner"
@@ -14,9 +14,13 @@ const (
 )
 
 func (p *Parser) nud(tokenText scanner.TokenText) (expression.Expression, error) {
+	if tokenText.Token == token.Illegal {
+		return nil, newUnexpectedError(tokenText)
+	}
+
 	fn, ok := p.nudFns[tokenText.Token]
 	if !ok {
-		return nil, fmt.Errorf("not implement null denotation %s", tokenText)
+		return nil, newParseError(tokenText, "not implement null denotation", nil)
 	}
 
 	return fn(tokenText)
@@ -25,25 +29,44 @@ func (p *Parser) nud(tokenText scanner.TokenText) (expression.Expression, error)
 func (p *Parser) nudBool(tokenText scanner.TokenText) (expression.Expression, error) {
 	value, err := strconv.ParseBool(tokenText.Text)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse bool %s: %w", tokenText, err)
+		return nil, newParseError(tokenText, "failed to parse bool", err)
 	}
 
 	return expression.NewBoolLiteral(value), nil
 }
 
 func (p *Parser) nudInt(tokenText scanner.TokenText) (expression.Expression, error) {
+	// only decimal is allowed so 010 is not read as 10 or octal 8
+	digits := strings.TrimPrefix(tokenText.Text, token.Minus.String())
+	if len(digits) > 1 && digits[0] == '0' {
+		return nil, newParseError(tokenText, "int must be decimal without leading zero", nil)
+	}
+
 	value, err := strconv.ParseInt(tokenText.Text, 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse int token %s: %w", tokenText, err)
+		return nil, newParseError(tokenText, "failed to parse int", err)
 	}
 
 	return expression.NewIntLiteral(value), nil
 }
 
+func (p *Parser) nudFloat(tokenText scanner.TokenText) (expression.Expression, error) {
+	value, err := strconv.ParseFloat(tokenText.Text, 64)
+	if err != nil {
+		return nil, newParseError(tokenText, "failed to parse float", err)
+	}
+
+	return expression.NewFloatLiteral(value), nil
+}
+
 func (p *Parser) nudString(tokenText scanner.TokenText) (expression.Expression, error) {
 	return expression.NewStringLiteral(tokenText.Text), nil
 }
 
+func (p *Parser) nudNull(tokenText scanner.TokenText) (expression.Expression, error) {
+	return expression.NewNullLiteral(), nil
+}
+
 func (p *Parser) nudVar(tokenText scanner.TokenText) (expression.Expression, error) {
 	return expression.NewVarExpression(tokenText.Text), nil
 }
@@ -57,24 +80,68 @@ func (p *Parser) nudNot(_ scanner.TokenText) (expression.Expression, error) {
 	return expression.NewUnaryExpression(token.Not, expr), nil
 }
 
+// -1 is parsed as int literal, not unary of int literal
+// so min int64 can be parsed
+func (p *Parser) nudMinus(_ scanner.TokenText) (expression.Expression, error) {
+	switch next := p.bs.Peek(); next.Token {
+	case token.Int, token.Float:
+		// consume number
+		p.bs.Scan()
+		next.Text = token.Minus.String() + next.Text
+		return p.nud(next)
+	}
+
+	expr, err := p.parseWithPrecedence(token.PrefixLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	return expression.NewUnaryExpression(token.Minus, expr), nil
+}
+
 func (p *Parser) nudOpenParenthesis(_ scanner.TokenText) (expression.Expression, error) {
 	expr, err := p.parseWithPrecedence(token.LowestLevel)
 	if err != nil {
 		return nil, err
 	}
 
-	if expect := p.bs.Scan(); expect.Token != token.CloseParenthesis {
-		return nil, fmt.Errorf("expect %s got %s", token.CloseParenthesis, expect)
+	if err := p.expect(token.CloseParenthesis); err != nil {
+		return nil, err
 	}
 
 	return expr, nil
 }
 
 func (p *Parser) nudSquareBracket(_ scanner.TokenText) (expression.Expression, error) {
-	children := make([]expression.Expression, 0, defaultNumberOfChildren)
+	children, err := p.parseList(token.CloseSquareBracket)
+	if err != nil {
+		return nil, err
+	}
 
+	return expression.NewArrayExpression(children...), nil
+}
+
+// Ident is only used as function name
+// name(arg1, arg2, ...)
+func (p *Parser) nudIdent(tokenText scanner.TokenText) (expression.Expression, error) {
+	if err := p.expect(token.OpenParenthesis); err != nil {
+		return nil, err
+	}
+
+	args, err := p.parseList(token.CloseParenthesis)
+	if err != nil {
+		return nil, err
+	}
+
+	return expression.NewCallExpression(tokenText.Text, args...), nil
+}
+
+// parseList parse expressions separated by comma until end token
+// end token is consumed
+func (p *Parser) parseList(end token.Token) ([]expression.Expression, error) {
+	children := make([]expression.Expression, 0, defaultNumberOfChildren)
 	for {
-		if p.bs.Peek().Token == token.CloseSquareBracket {
+		if p.bs.Peek().Token == end {
 			break
 		}
 
@@ -86,6 +153,13 @@ func (p *Parser) nudSquareBracket(_ scanner.TokenText) (expression.Expression, e
 
 		children = append(children, child)
 
+		if p.isRecovering {
+			if next := p.bs.Peek().Token; next != token.Comma && next != end {
+				// skip until , or end then continue with next child
+				p.recover(newExpectError(p.bs.Peek(), token.Comma, end))
+			}
+		}
+
 		if p.bs.Peek().Token != token.Comma {
 			break
 		}
@@ -94,9 +168,12 @@ func (p *Parser) nudSquareBracket(_ scanner.TokenText) (expression.Expression, e
 		p.bs.Scan()
 	}
 
-	if expect := p.bs.Scan(); expect.Token != token.CloseSquareBracket {
-		return nil, fmt.Errorf("expect %s got %s", token.CloseSquareBracket, expect)
+	if expect := p.bs.Peek(); expect.Token != end {
+		return nil, newExpectError(expect, token.Comma, end)
 	}
 
-	return expression.NewArrayExpression(children...), nil
+	// consume end
+	p.bs.Scan()
+
+	return children, nil
 }
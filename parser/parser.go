@@ -3,7 +3,6 @@
 package parser
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/haunt98/evaluator/expression"
@@ -16,6 +15,13 @@ type Parser struct {
 
 	nudFns map[token.Token]nudFn
 	ledFns map[token.Token]ledFn
+
+	// recovering mode, see ParseAll
+	isRecovering bool
+	errs         ParseErrors
+	// offset of token where parser synchronized
+	// errors at this token are caused by previous error
+	syncOffset int
 }
 
 // nud short for null denotation
@@ -33,53 +39,110 @@ func NewParser(input string) *Parser {
 	}
 
 	p.nudFns = map[token.Token]nudFn{
+		token.Ident:             p.nudIdent,
 		token.Bool:              p.nudBool,
 		token.Int:               p.nudInt,
+		token.Float:             p.nudFloat,
 		token.String:            p.nudString,
+		token.Null:              p.nudNull,
 		token.Var:               p.nudVar,
 		token.Not:               p.nudNot,
+		token.Minus:             p.nudMinus,
 		token.OpenParenthesis:   p.nudOpenParenthesis,
 		token.OpenSquareBracket: p.nudSquareBracket,
 	}
 
 	p.ledFns = map[token.Token]ledFn{
-		token.Or:             p.ledInfix,
-		token.And:            p.ledInfix,
-		token.Equal:          p.ledInfix,
-		token.NotEqual:       p.ledInfix,
-		token.Less:           p.ledInfix,
-		token.LessOrEqual:    p.ledInfix,
-		token.Greater:        p.ledInfix,
-		token.GreaterOrEqual: p.ledInfix,
-		token.In:             p.ledInfix,
-		token.NotIn:          p.ledInfix,
+		token.Or:                p.ledInfix,
+		token.And:               p.ledInfix,
+		token.Equal:             p.ledInfix,
+		token.NotEqual:          p.ledInfix,
+		token.Less:              p.ledInfix,
+		token.LessOrEqual:       p.ledInfix,
+		token.Greater:           p.ledInfix,
+		token.GreaterOrEqual:    p.ledInfix,
+		token.In:                p.ledInfix,
+		token.NotIn:             p.ledInfix,
+		token.Plus:              p.ledInfix,
+		token.Minus:             p.ledInfix,
+		token.Multiply:          p.ledInfix,
+		token.Divide:            p.ledInfix,
+		token.Modulo:            p.ledInfix,
+		token.Dot:               p.ledDot,
+		token.OpenSquareBracket: p.ledSquareBracket,
 	}
 
 	return p
 }
 
+// Parse parse whole input to expression
+// Input must be consumed until EOF, trailing tokens are error
+// Error is *ParseError of the first syntax error
 func (p *Parser) Parse() (expression.Expression, error) {
-	return p.parseWithPrecedence(token.LowestLevel)
+	result, err := p.parseWithPrecedence(token.LowestLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenText := p.bs.Scan(); tokenText.Token != token.EOF {
+		return nil, newUnexpectedError(tokenText)
+	}
+
+	return result, nil
 }
 
 func (p *Parser) parseWithPrecedence(precedence int) (expression.Expression, error) {
+	if p.isRecovering && isSynchronizer(p.bs.Peek().Token) {
+		// expression is missing, keep token so caller can continue from it
+		return p.recover(newParseError(p.bs.Peek(), "not implement null denotation", nil)), nil
+	}
+
 	tokenText := p.bs.Scan()
 	result, err := p.nud(tokenText)
 	if err != nil {
-		return nil, fmt.Errorf("failed to null denotation %s: %w", tokenText, err)
+		if !p.isRecovering {
+			return nil, err
+		}
+
+		result = p.recover(err)
 	}
 
+	return p.parseLeft(precedence, result)
+}
+
+// parseLeft parse left denotations of result while next token has higher precedence
+func (p *Parser) parseLeft(precedence int, result expression.Expression) (expression.Expression, error) {
 	for {
 		if precedence >= p.bs.Peek().Token.Precedence() {
 			break
 		}
 
-		tokenText = p.bs.Scan()
+		tokenText := p.bs.Scan()
+
+		var err error
 		result, err = p.led(tokenText, result)
 		if err != nil {
-			return nil, fmt.Errorf("failed to left denotation %s: %w", tokenText, err)
+			if !p.isRecovering {
+				return nil, err
+			}
+
+			result = p.recover(err)
 		}
 	}
 
 	return result, nil
 }
+
+// expect consume next token if it is one of expected tokens
+// otherwise next token is kept so parser can recover from it
+func (p *Parser) expect(expected ...token.Token) error {
+	tokenText := p.bs.Peek()
+	for _, e := range expected {
+		if tokenText.Token == e {
+			p.bs.Scan()
+			return nil
+		}
+	}
+
+	return newExpectError(tokenText, expected...)
+}
@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/haunt98/evaluator/expression"
@@ -32,11 +34,51 @@ func generateTestCaseLiteral() []testCase {
 			input:    "1",
 			wantExpr: expression.NewIntLiteral(1),
 		},
+		{
+			name:     "int zero",
+			input:    "0",
+			wantExpr: expression.NewIntLiteral(0),
+		},
+		{
+			name:     "int ten",
+			input:    "10",
+			wantExpr: expression.NewIntLiteral(10),
+		},
+		{
+			name:     "float",
+			input:    "1.5",
+			wantExpr: expression.NewFloatLiteral(1.5),
+		},
+		{
+			name:     "float exponent",
+			input:    "1e-3",
+			wantExpr: expression.NewFloatLiteral(0.001),
+		},
 		{
 			name:     "string",
 			input:    `"a"`,
 			wantExpr: expression.NewStringLiteral("a"),
 		},
+		{
+			name:     "null",
+			input:    "null",
+			wantExpr: expression.NewNullLiteral(),
+		},
+		{
+			name:     "string escape",
+			input:    `"\"a\"\n"`,
+			wantExpr: expression.NewStringLiteral("\"a\"\n"),
+		},
+		{
+			name:     "string single quoted",
+			input:    `'"a"'`,
+			wantExpr: expression.NewStringLiteral(`"a"`),
+		},
+		{
+			name:     "string raw",
+			input:    "`\\d+`",
+			wantExpr: expression.NewStringLiteral(`\d+`),
+		},
 	}
 }
 
@@ -188,6 +230,14 @@ func generateTestCaseBinary() []testCase {
 				expression.NewIntLiteral(2),
 			),
 		},
+		{
+			name:  "greater or equal float",
+			input: "$score >= 0.75",
+			wantExpr: expression.NewBinaryExpression(token.GreaterOrEqual,
+				expression.NewVarExpression("score"),
+				expression.NewFloatLiteral(0.75),
+			),
+		},
 		{
 			name:  "greater or equal",
 			input: "1 >= 2",
@@ -221,6 +271,255 @@ func generateTestCaseBinary() []testCase {
 	}
 }
 
+func generateTestCaseArithmetic() []testCase {
+	return []testCase{
+		{
+			name:     "negative int",
+			input:    "-1",
+			wantExpr: expression.NewIntLiteral(-1),
+		},
+		{
+			name:     "negative min int",
+			input:    "-9223372036854775808",
+			wantExpr: expression.NewIntLiteral(-9223372036854775808),
+		},
+		{
+			name:     "negative float",
+			input:    "-1.5",
+			wantExpr: expression.NewFloatLiteral(-1.5),
+		},
+		{
+			name:     "negative var",
+			input:    "-$x",
+			wantExpr: expression.NewUnaryExpression(token.Minus, expression.NewVarExpression("x")),
+		},
+		{
+			name:  "negative var multiply",
+			input: "-$x * 2",
+			wantExpr: expression.NewBinaryExpression(token.Multiply,
+				expression.NewUnaryExpression(token.Minus, expression.NewVarExpression("x")),
+				expression.NewIntLiteral(2),
+			),
+		},
+		{
+			name:  "plus multiply",
+			input: "1 + 2 * 3",
+			wantExpr: expression.NewBinaryExpression(token.Plus,
+				expression.NewIntLiteral(1),
+				expression.NewBinaryExpression(token.Multiply,
+					expression.NewIntLiteral(2),
+					expression.NewIntLiteral(3),
+				),
+			),
+		},
+		{
+			name:  "parenthesis plus multiply",
+			input: "(1 + 2) * 3",
+			wantExpr: expression.NewBinaryExpression(token.Multiply,
+				expression.NewBinaryExpression(token.Plus,
+					expression.NewIntLiteral(1),
+					expression.NewIntLiteral(2),
+				),
+				expression.NewIntLiteral(3),
+			),
+		},
+		{
+			name:  "minus minus",
+			input: "1 - 2 - 3",
+			wantExpr: expression.NewBinaryExpression(token.Minus,
+				expression.NewBinaryExpression(token.Minus,
+					expression.NewIntLiteral(1),
+					expression.NewIntLiteral(2),
+				),
+				expression.NewIntLiteral(3),
+			),
+		},
+		{
+			name:  "divide modulo",
+			input: "7 / 2 % 3",
+			wantExpr: expression.NewBinaryExpression(token.Modulo,
+				expression.NewBinaryExpression(token.Divide,
+					expression.NewIntLiteral(7),
+					expression.NewIntLiteral(2),
+				),
+				expression.NewIntLiteral(3),
+			),
+		},
+		{
+			name:  "multiply greater",
+			input: "$price * $qty > 1000",
+			wantExpr: expression.NewBinaryExpression(token.Greater,
+				expression.NewBinaryExpression(token.Multiply,
+					expression.NewVarExpression("price"),
+					expression.NewVarExpression("qty"),
+				),
+				expression.NewIntLiteral(1000),
+			),
+		},
+	}
+}
+
+func generateTestCaseCall() []testCase {
+	return []testCase{
+		{
+			name:     "call no args",
+			input:    "now()",
+			wantExpr: expression.NewCallExpression("now"),
+		},
+		{
+			name:  "call single arg",
+			input: "len($tags) > 2",
+			wantExpr: expression.NewBinaryExpression(token.Greater,
+				expression.NewCallExpression("len", expression.NewVarExpression("tags")),
+				expression.NewIntLiteral(2),
+			),
+		},
+		{
+			name:  "call multi args",
+			input: `contains(lower($country), "vn")`,
+			wantExpr: expression.NewCallExpression("contains",
+				expression.NewCallExpression("lower", expression.NewVarExpression("country")),
+				expression.NewStringLiteral("vn"),
+			),
+		},
+	}
+}
+
+func generateTestCaseMember() []testCase {
+	return []testCase{
+		{
+			name:  "member",
+			input: "$user.name",
+			wantExpr: expression.NewMemberExpression(
+				expression.NewVarExpression("user"),
+				"name",
+			),
+		},
+		{
+			name:  "member nested",
+			input: `$user.profile.country == "vn"`,
+			wantExpr: expression.NewBinaryExpression(token.Equal,
+				expression.NewMemberExpression(
+					expression.NewMemberExpression(
+						expression.NewVarExpression("user"),
+						"profile",
+					),
+					"country",
+				),
+				expression.NewStringLiteral("vn"),
+			),
+		},
+		{
+			name:  "member keyword",
+			input: "$a.in in $b.or",
+			wantExpr: expression.NewBinaryExpression(token.In,
+				expression.NewMemberExpression(expression.NewVarExpression("a"), "in"),
+				expression.NewMemberExpression(expression.NewVarExpression("b"), "or"),
+			),
+		},
+		{
+			name:  "member keyword literal",
+			input: "$a.null == $a.true",
+			wantExpr: expression.NewBinaryExpression(token.Equal,
+				expression.NewMemberExpression(expression.NewVarExpression("a"), "null"),
+				expression.NewMemberExpression(expression.NewVarExpression("a"), "true"),
+			),
+		},
+		{
+			name:  "member keyword chain",
+			input: "$a.and.notin",
+			wantExpr: expression.NewMemberExpression(
+				expression.NewMemberExpression(expression.NewVarExpression("a"), "and"),
+				"notin",
+			),
+		},
+		{
+			name:  "member negative",
+			input: "-$a.b",
+			wantExpr: expression.NewUnaryExpression(token.Minus,
+				expression.NewMemberExpression(
+					expression.NewVarExpression("a"),
+					"b",
+				),
+			),
+		},
+	}
+}
+
+func generateTestCaseIndex() []testCase {
+	return []testCase{
+		{
+			name:  "index int",
+			input: "$items[0]",
+			wantExpr: expression.NewIndexExpression(
+				expression.NewVarExpression("items"),
+				expression.NewIntLiteral(0),
+			),
+		},
+		{
+			name:  "index string",
+			input: `$headers["x-id"]`,
+			wantExpr: expression.NewIndexExpression(
+				expression.NewVarExpression("headers"),
+				expression.NewStringLiteral("x-id"),
+			),
+		},
+		{
+			name:  "index chain member",
+			input: "$a.b[2].c",
+			wantExpr: expression.NewMemberExpression(
+				expression.NewIndexExpression(
+					expression.NewMemberExpression(
+						expression.NewVarExpression("a"),
+						"b",
+					),
+					expression.NewIntLiteral(2),
+				),
+				"c",
+			),
+		},
+		{
+			name:  "index expression",
+			input: "$a[$i + 1][0]",
+			wantExpr: expression.NewIndexExpression(
+				expression.NewIndexExpression(
+					expression.NewVarExpression("a"),
+					expression.NewBinaryExpression(token.Plus,
+						expression.NewVarExpression("i"),
+						expression.NewIntLiteral(1),
+					),
+				),
+				expression.NewIntLiteral(0),
+			),
+		},
+		{
+			name:  "index array literal",
+			input: "[1, 2][0]",
+			wantExpr: expression.NewIndexExpression(
+				expression.NewArrayExpression(
+					expression.NewIntLiteral(1),
+					expression.NewIntLiteral(2),
+				),
+				expression.NewIntLiteral(0),
+			),
+		},
+		{
+			name:  "index in array",
+			input: "$a[0] in [1, 2]",
+			wantExpr: expression.NewBinaryExpression(token.In,
+				expression.NewIndexExpression(
+					expression.NewVarExpression("a"),
+					expression.NewIntLiteral(0),
+				),
+				expression.NewArrayExpression(
+					expression.NewIntLiteral(1),
+					expression.NewIntLiteral(2),
+				),
+			),
+		},
+	}
+}
+
 func generateTestCaseComplex() []testCase {
 	return []testCase{
 		{
@@ -284,6 +583,10 @@ func TestParserParse(t *testing.T) {
 	tests = append(tests, generateTestCaseParenthesis()...)
 	tests = append(tests, generateTestCaseArray()...)
 	tests = append(tests, generateTestCaseBinary()...)
+	tests = append(tests, generateTestCaseArithmetic()...)
+	tests = append(tests, generateTestCaseCall()...)
+	tests = append(tests, generateTestCaseMember()...)
+	tests = append(tests, generateTestCaseIndex()...)
 	tests = append(tests, generateTestCaseComplex()...)
 
 	for _, tc := range tests {
@@ -299,3 +602,272 @@ func TestParserParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParserParseError(t *testing.T) {
+	tests := []struct {
+		input        string
+		wantLine     int
+		wantColumn   int
+		wantFound    token.Token
+		wantExpected []token.Token
+	}{
+		{
+			input:        "x",
+			wantLine:     1,
+			wantColumn:   2,
+			wantFound:    token.EOF,
+			wantExpected: []token.Token{token.OpenParenthesis},
+		},
+		{
+			input:      "len(",
+			wantLine:   1,
+			wantColumn: 5,
+			wantFound:  token.EOF,
+		},
+		{
+			input:        "len($x",
+			wantLine:     1,
+			wantColumn:   7,
+			wantFound:    token.EOF,
+			wantExpected: []token.Token{token.Comma, token.CloseParenthesis},
+		},
+		{
+			input:      "len($x,",
+			wantLine:   1,
+			wantColumn: 8,
+			wantFound:  token.EOF,
+		},
+		{
+			input:        "$a.",
+			wantLine:     1,
+			wantColumn:   4,
+			wantFound:    token.EOF,
+			wantExpected: []token.Token{token.Ident},
+		},
+		{
+			input:        "$a.(",
+			wantLine:     1,
+			wantColumn:   4,
+			wantFound:    token.OpenParenthesis,
+			wantExpected: []token.Token{token.Ident},
+		},
+		{
+			input:      "$a[",
+			wantLine:   1,
+			wantColumn: 4,
+			wantFound:  token.EOF,
+		},
+		{
+			input:        "$a[0",
+			wantLine:     1,
+			wantColumn:   5,
+			wantFound:    token.EOF,
+			wantExpected: []token.Token{token.CloseSquareBracket},
+		},
+		{
+			input:      "$a[]",
+			wantLine:   1,
+			wantColumn: 4,
+			wantFound:  token.CloseSquareBracket,
+		},
+		{
+			input:      "true false",
+			wantLine:   1,
+			wantColumn: 6,
+			wantFound:  token.Bool,
+		},
+		{
+			input:      "$a == 1 )",
+			wantLine:   1,
+			wantColumn: 9,
+			wantFound:  token.CloseParenthesis,
+		},
+		{
+			input:      "$a = 1",
+			wantLine:   1,
+			wantColumn: 4,
+			wantFound:  token.Illegal,
+		},
+		{
+			input:      "= 1",
+			wantLine:   1,
+			wantColumn: 1,
+			wantFound:  token.Illegal,
+		},
+		{
+			input:      "$a == #",
+			wantLine:   1,
+			wantColumn: 7,
+			wantFound:  token.Illegal,
+		},
+		{
+			input:      `$a == "b`,
+			wantLine:   1,
+			wantColumn: 7,
+			wantFound:  token.Illegal,
+		},
+		{
+			input:      "010",
+			wantLine:   1,
+			wantColumn: 1,
+			wantFound:  token.Int,
+		},
+		{
+			input:      "0x10",
+			wantLine:   1,
+			wantColumn: 1,
+			wantFound:  token.Int,
+		},
+		{
+			input:      "$a == -010",
+			wantLine:   1,
+			wantColumn: 8,
+			wantFound:  token.Int,
+		},
+		{
+			input:        "$a ==\n  (1 2",
+			wantLine:     2,
+			wantColumn:   6,
+			wantFound:    token.Int,
+			wantExpected: []token.Token{token.CloseParenthesis},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			p := NewParser(tc.input)
+
+			_, gotErr := p.Parse()
+
+			var gotParseErr *ParseError
+			assert.True(t, errors.As(gotErr, &gotParseErr))
+			assert.Equal(t, tc.wantLine, gotParseErr.Line)
+			assert.Equal(t, tc.wantColumn, gotParseErr.Column)
+			assert.Equal(t, tc.wantFound, gotParseErr.Found.Token)
+			assert.Equal(t, tc.wantExpected, gotParseErr.Expected)
+		})
+	}
+}
+
+func TestParserParseAll(t *testing.T) {
+	var tests []testCase
+	tests = append(tests, generateTestCaseLiteral()...)
+	tests = append(tests, generateTestCaseBinary()...)
+	tests = append(tests, generateTestCaseCall()...)
+	tests = append(tests, generateTestCaseComplex()...)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewParser(tc.input)
+
+			gotExpr, gotErrs := p.ParseAll()
+			assert.Empty(t, gotErrs)
+			assert.Equal(t, tc.wantExpr, gotExpr)
+		})
+	}
+}
+
+func TestParserParseAllRecover(t *testing.T) {
+	tests := []struct {
+		input string
+		// use String because bad expression contains error
+		wantExpr      string
+		wantPositions []string
+	}{
+		{
+			input:         "$a == and $b ==",
+			wantExpr:      "Vara == Bad And Varb == Bad",
+			wantPositions: []string{"1:7", "1:16"},
+		},
+		{
+			input:         "[1 2, 3]",
+			wantExpr:      "[1 ,3]",
+			wantPositions: []string{"1:4"},
+		},
+		{
+			input:         "f(1, , 3) or $x.",
+			wantExpr:      "f(1, Bad, 3) Or Bad",
+			wantPositions: []string{"1:6", "1:17"},
+		},
+		{
+			input:         "((",
+			wantExpr:      "Bad",
+			wantPositions: []string{"1:3"},
+		},
+		{
+			input:         "$a == 1 ) or $b",
+			wantExpr:      "Vara == 1 Or Varb",
+			wantPositions: []string{"1:9"},
+		},
+		{
+			input:         "$a = 1 and # or (1 2",
+			wantExpr:      "Vara And Bad Or Bad",
+			wantPositions: []string{"1:4", "1:12", "1:20"},
+		},
+		{
+			input:         "$a == )",
+			wantExpr:      "Vara == Bad",
+			wantPositions: []string{"1:7"},
+		},
+		{
+			input:         "true false",
+			wantExpr:      "true",
+			wantPositions: []string{"1:6"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			p := NewParser(tc.input)
+
+			gotExpr, gotErrs := p.ParseAll()
+			assert.Equal(t, tc.wantExpr, gotExpr.String())
+
+			gotPositions := make([]string, len(gotErrs))
+			for i, gotErr := range gotErrs {
+				gotPositions[i] = fmt.Sprintf("%d:%d", gotErr.Line, gotErr.Column)
+			}
+			assert.Equal(t, tc.wantPositions, gotPositions)
+		})
+	}
+}
+
+func TestBadExpressionAccept(t *testing.T) {
+	p := NewParser("$a ==")
+
+	gotExpr, gotErrs := p.ParseAll()
+	assert.Len(t, gotErrs, 1)
+
+	binaryExpr, ok := gotExpr.(*expression.BinaryExpression)
+	assert.True(t, ok)
+
+	// bad expression keep its error when visited
+	_, gotErr := binaryExpr.Right.Accept(nil)
+	assert.Equal(t, gotErrs[0], gotErr)
+}
+
+func TestParseErrorSnippet(t *testing.T) {
+	input := "$a == 1 and\n\t($b or $c"
+
+	p := NewParser(input)
+	_, gotErr := p.Parse()
+	assert.Error(t, gotErr)
+
+	want := "2:11: expect ) got token EOF text \n" +
+		"\t($b or $c\n" +
+		"\t         ^"
+	assert.Equal(t, want, FormatError(input, gotErr))
+
+	assert.Equal(t, "other", FormatError(input, errors.New("other")))
+}
+
+func TestParseErrorIllegalString(t *testing.T) {
+	_, gotErr := NewParser(`$a == 'b`).Parse()
+	assert.EqualError(t, gotErr, "1:7: illegal token Illegal text 'b: literal not terminated")
+}
+
+func TestParseErrorNested(t *testing.T) {
+	_, gotErr := NewParser("$a == ($b + ]").Parse()
+	assert.IsType(t, &ParseError{}, gotErr)
+	assert.EqualError(t, gotErr, "1:13: not implement null denotation token ] text ]")
+}
@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/token"
+)
+
+// ParseAll parse whole input and report all syntax errors instead of stopping at first one
+// Invalid parts of input are replaced by bad expressions in result
+// Parser synchronizes on comma, closing brackets, or, and
+func (p *Parser) ParseAll() (expression.Expression, ParseErrors) {
+	p.isRecovering = true
+	p.errs = nil
+	p.syncOffset = -1
+
+	// error is always recovered in recovering mode
+	result, _ := p.parseWithPrecedence(token.LowestLevel)
+
+	for {
+		tokenText := p.bs.Peek()
+		if tokenText.Token == token.EOF {
+			break
+		}
+
+		p.addError(newUnexpectedError(tokenText))
+
+		// consume unexpected token then continue with or, and if any
+		p.bs.Scan()
+		p.synchronize()
+		result, _ = p.parseLeft(token.LowestLevel, result)
+	}
+
+	return result, p.errs
+}
+
+// recover record error then skip tokens until synchronizer
+// return bad expression as placeholder
+func (p *Parser) recover(err error) expression.Expression {
+	if parseErr, ok := err.(*ParseError); ok {
+		p.addError(parseErr)
+	} else {
+		p.addError(newParseError(p.bs.Peek(), "failed to parse", err))
+	}
+
+	p.synchronize()
+
+	return expression.NewBadExpression(err)
+}
+
+func (p *Parser) addError(err *ParseError) {
+	if err.Offset == p.syncOffset {
+		return
+	}
+
+	p.errs = append(p.errs, err)
+}
+
+// synchronize skip tokens until synchronizer which is not inside skipped brackets
+// synchronizer is not consumed
+func (p *Parser) synchronize() {
+	depth := 0
+	for {
+		tokenText := p.bs.Peek()
+		if tokenText.Token == token.EOF {
+			break
+		}
+
+		if depth == 0 && isSynchronizer(tokenText.Token) {
+			break
+		}
+
+		switch tokenText.Token {
+		case token.OpenParenthesis, token.OpenSquareBracket:
+			depth++
+		case token.CloseParenthesis, token.CloseSquareBracket:
+			depth--
+		}
+
+		p.bs.Scan()
+	}
+
+	p.syncOffset = p.bs.Peek().Pos.Offset
+}
+
+func isSynchronizer(t token.Token) bool {
+	switch t {
+	case token.EOF,
+		token.Comma,
+		token.CloseParenthesis,
+		token.CloseSquareBracket,
+		token.Or,
+		token.And:
+		return true
+	default:
+		return false
+	}
+}
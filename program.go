@@ -0,0 +1,116 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/haunt98/evaluator/builtin"
+	"github.com/haunt98/evaluator/evaluate"
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/optimize"
+	"github.com/haunt98/evaluator/parser"
+	"github.com/haunt98/evaluator/schema"
+	"github.com/haunt98/evaluator/typecheck"
+)
+
+// Program is parsed input, which can be evaluated many times
+// Program is immutable so it is safe to eval concurrently
+type Program struct {
+	input    string
+	expr     expression.Expression
+	registry *evaluate.Registry
+	schema   schema.Schema
+}
+
+// defaultRegistry is used if WithRegistry is not set
+var defaultRegistry = builtin.NewRegistry()
+
+type Option func(prog *Program)
+
+// WithRegistry allow program to call functions in registry instead of builtin functions
+// Use builtin.NewRegistry then register more functions to extend builtin functions
+// Registry must not be changed after compile
+func WithRegistry(registry *evaluate.Registry) Option {
+	return func(prog *Program) {
+		prog.registry = registry
+	}
+}
+
+// WithSchema reject input which use undeclared vars or has type mismatches when compile
+// and reject invalid args when eval
+func WithSchema(s schema.Schema) Option {
+	return func(prog *Program) {
+		prog.schema = s
+	}
+}
+
+// Compile parse input once to program, then optimize it because program is evaluated many times
+func Compile(input string, opts ...Option) (*Program, error) {
+	return compile(input, true, opts...)
+}
+
+// compile skip optimize if isOptimize is false, for example input is evaluated once
+func compile(input string, isOptimize bool, opts ...Option) (*Program, error) {
+	prog := &Program{
+		input:    input,
+		registry: defaultRegistry,
+	}
+
+	for _, opt := range opts {
+		opt(prog)
+	}
+
+	p := parser.NewParser(input)
+
+	expr, err := p.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", input, err)
+	}
+
+	if prog.schema != nil {
+		if err := prog.schema.Check(expr, typecheck.WithRegistry(prog.registry)); err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", input, err)
+		}
+	}
+
+	if isOptimize {
+		expr, err = optimize.Optimize(expr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to optimize %s: %w", input, err)
+		}
+	}
+
+	prog.expr = expr
+
+	return prog, nil
+}
+
+// Eval evaluate program with args
+// Each eval use its own visitor, parsed expression is only read
+func (prog *Program) Eval(args map[string]interface{}) (interface{}, error) {
+	if prog.schema != nil {
+		if err := prog.schema.Validate(args); err != nil {
+			return nil, fmt.Errorf("invalid args of %s: %w", prog.input, err)
+		}
+	}
+
+	v := evaluate.NewVisitor(args, evaluate.WithRegistry(prog.registry))
+
+	result, err := v.Visit(prog.expr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate %s: %w", prog.input, err)
+	}
+
+	return evaluate.Value(result)
+}
+
+func (prog *Program) EvalBool(args map[string]interface{}) (bool, error) {
+	return toBool(prog.Eval(args))
+}
+
+func (prog *Program) EvalInt(args map[string]interface{}) (int64, error) {
+	return toInt(prog.Eval(args))
+}
+
+func (prog *Program) EvalString(args map[string]interface{}) (string, error) {
+	return toString(prog.Eval(args))
+}
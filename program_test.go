@@ -0,0 +1,187 @@
+package evaluator
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/haunt98/evaluator/schema"
+	"github.com/haunt98/evaluator/typecheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompile(t *testing.T) {
+	_, gotErr := Compile("(true")
+	assert.Error(t, gotErr)
+
+	_, gotErr = Compile("$x == 1")
+	assert.NoError(t, gotErr)
+}
+
+func TestProgramEval(t *testing.T) {
+	prog, err := Compile(`$x in [1, 2] and $y == "a"`)
+	assert.NoError(t, err)
+
+	tests := []testCase{
+		{
+			name: "true",
+			inputArgs: map[string]interface{}{
+				"x": 1,
+				"y": "a",
+			},
+			wantResult: true,
+		},
+		{
+			name: "false",
+			inputArgs: map[string]interface{}{
+				"x": 3,
+				"y": "a",
+			},
+			wantResult: false,
+		},
+		{
+			name: "args missing",
+			inputArgs: map[string]interface{}{
+				"x": 1,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotResult, gotErr := prog.Eval(tc.inputArgs)
+			if tc.wantErr {
+				assert.Error(t, gotErr)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tc.wantResult, gotResult)
+		})
+	}
+}
+
+func TestProgramEvalConcurrent(t *testing.T) {
+	prog, err := Compile("$x > 10 or $x in [1, 2, 3]")
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(x int) {
+			defer wg.Done()
+
+			got, err := prog.EvalBool(map[string]interface{}{
+				"x": x,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, x > 10 || (x >= 1 && x <= 3), got)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestProgramWithSchema(t *testing.T) {
+	s := schema.Schema{
+		"age": {
+			Type:     typecheck.Int,
+			Required: true,
+		},
+	}
+
+	_, gotErr := Compile("$x > 1", WithSchema(s))
+	assert.Error(t, gotErr)
+
+	_, gotErr = Compile(`$age == "ten"`, WithSchema(s))
+	assert.Error(t, gotErr)
+
+	prog, gotErr := Compile("$age > 18", WithSchema(s))
+	assert.NoError(t, gotErr)
+
+	_, gotErr = prog.Eval(map[string]interface{}{})
+	assert.Error(t, gotErr)
+
+	gotResult, gotErr := prog.EvalBool(map[string]interface{}{
+		"age": 20,
+	})
+	assert.NoError(t, gotErr)
+	assert.True(t, gotResult)
+}
+
+func TestProgramWithSchemaObject(t *testing.T) {
+	type user struct {
+		Name string `evaluator:"name"`
+	}
+
+	s := schema.Schema{
+		"user": {
+			Type: typecheck.ObjectOf(map[string]*typecheck.Type{
+				"name": typecheck.String,
+			}),
+		},
+	}
+
+	prog, gotErr := Compile(`$user["name"] == $user.name`, WithSchema(s))
+	assert.NoError(t, gotErr)
+
+	gotResult, gotErr := prog.EvalBool(map[string]interface{}{
+		"user": &user{Name: "a"},
+	})
+	assert.NoError(t, gotErr)
+	assert.True(t, gotResult)
+
+	// optional var can be null
+	prog, gotErr = Compile("$user == null", WithSchema(s))
+	assert.NoError(t, gotErr)
+
+	gotResult, gotErr = prog.EvalBool(map[string]interface{}{
+		"user": nil,
+	})
+	assert.NoError(t, gotErr)
+	assert.True(t, gotResult)
+}
+
+func TestProgramEvalSet(t *testing.T) {
+	prog, err := Compile(newAllowList(10))
+	assert.NoError(t, err)
+
+	for x, want := range map[interface{}]bool{
+		"user-9":  true,
+		"user-10": false,
+		1:         false,
+		nil:       false,
+	} {
+		got, err := prog.EvalBool(map[string]interface{}{
+			"x": x,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, x)
+	}
+}
+
+func newAllowList(n int) string {
+	users := make([]string, n)
+	for i := range users {
+		users[i] = fmt.Sprintf(`"user-%d"`, i)
+	}
+
+	return "$x in [" + strings.Join(users, ", ") + "]"
+}
+
+func BenchmarkProgramEvalSet(b *testing.B) {
+	prog, err := Compile(newAllowList(1000))
+	assert.NoError(b, err)
+
+	args := map[string]interface{}{
+		"x": "user-999",
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := prog.Eval(args); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package scanner
 import (
 	"strings"
 	"testing"
+	"text/scanner"
 
 	"github.com/haunt98/evaluator/token"
 	"github.com/stretchr/testify/assert"
@@ -77,6 +78,8 @@ func TestBufferScannerScan(t *testing.T) {
 
 			for _, want := range tc.wants {
 				got := bufferScanner.Scan()
+				// position is tested in TestScannerScanPosition
+				got.Pos = scanner.Position{}
 				assert.Equal(t, want, got)
 			}
 		})
@@ -130,6 +133,8 @@ func TestBufferScannerPeek(t *testing.T) {
 
 			for _, want := range tc.wants {
 				got := bufferScanner.Peek()
+				// position is tested in TestScannerScanPosition
+				got.Pos = scanner.Position{}
 				assert.Equal(t, want, got)
 			}
 		})
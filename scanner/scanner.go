@@ -1,32 +1,61 @@
 package scanner
 
 import (
+	"errors"
 	"io"
+	"strconv"
 	"strings"
 	"text/scanner"
 
 	"github.com/haunt98/evaluator/token"
 )
 
+var errNotTerminated = errors.New("literal not terminated")
+
 type Scanner struct {
 	textScanner *scanner.Scanner
+	// err is first error of text scanner in current token
+	err error
 }
 
 func NewScanner(r io.Reader) *Scanner {
-	textScanner := &scanner.Scanner{}
-	textScanner.Mode = scanner.ScanIdents | scanner.ScanStrings | scanner.ScanInts
-	textScanner.Init(r)
+	s := &Scanner{
+		textScanner: &scanner.Scanner{},
+	}
 
-	return &Scanner{
-		textScanner: textScanner,
+	s.textScanner.Init(r)
+	// Init reset Mode so Mode must be set after
+	s.textScanner.Mode = scanner.ScanIdents | scanner.ScanStrings | scanner.ScanRawStrings | scanner.ScanInts | scanner.ScanFloats
+	// default is printing to stderr
+	s.textScanner.Error = func(_ *scanner.Scanner, msg string) {
+		if s.err == nil {
+			s.err = errors.New(msg)
+		}
 	}
+
+	return s
 }
 
-func (s *Scanner) Scan() (result TokenText) {
+// Scan return next token
+// Token is illegal with Err if input is wrong such as unterminated string
+func (s *Scanner) Scan() TokenText {
+	s.err = nil
+
+	result := s.scan()
+	if result.Err == nil && s.err != nil {
+		result.Token = token.Illegal
+		result.Err = s.err
+	}
+
+	return result
+}
+
+func (s *Scanner) scan() (result TokenText) {
 	ch := s.textScanner.Scan()
 	text := s.textScanner.TokenText()
 
 	result.Text = text
+	result.Pos = s.textScanner.Position
 
 	switch ch {
 	case scanner.EOF:
@@ -37,6 +66,9 @@ func (s *Scanner) Scan() (result TokenText) {
 		case "true", "false":
 			result.Token = token.Bool
 			result.Text = lowerText
+		case "null":
+			result.Token = token.Null
+			result.Text = lowerText
 		case "or":
 			result.Token = token.Or
 			result.Text = lowerText
@@ -54,11 +86,21 @@ func (s *Scanner) Scan() (result TokenText) {
 		}
 	case scanner.Int:
 		result.Token = token.Int
-	case scanner.String:
-		result.Token = token.String
-		// remove ""
-		// "abc" -> abc
-		result.Text = strings.Trim(result.Text, `"`)
+	case scanner.Float:
+		result.Token = token.Float
+	case scanner.String, scanner.RawString:
+		// "a\"b" -> a"b
+		// `a\"b` -> a\"b
+		return s.unquote(result, strconv.Unquote)
+	case '\'':
+		// text scanner only supports single character with ''
+		if err := s.scanSingleQuoted(&result); err != nil {
+			result.Token = token.Illegal
+			result.Err = err
+			return
+		}
+
+		return s.unquote(result, unquoteSingle)
 	case '$':
 		result.Token = token.Var
 		// consume next
@@ -66,13 +108,15 @@ func (s *Scanner) Scan() (result TokenText) {
 		result.Text = s.textScanner.TokenText()
 		return
 	case '=':
-		if expect := s.textScanner.Scan(); expect != '=' {
+		if expect := s.textScanner.Peek(); expect != '=' {
+			// do not consume next so it is scanned as its own token
 			result.Token = token.Illegal
-			result.Text += s.textScanner.TokenText()
 			return
 		}
 
 		result.Token = token.Equal
+		// consume =
+		_ = s.textScanner.Scan()
 		result.Text += s.textScanner.TokenText()
 	case '!':
 		if expect := s.textScanner.Peek(); expect == '=' {
@@ -104,6 +148,16 @@ func (s *Scanner) Scan() (result TokenText) {
 		}
 
 		result.Token = token.Greater
+	case '+':
+		result.Token = token.Plus
+	case '-':
+		result.Token = token.Minus
+	case '*':
+		result.Token = token.Multiply
+	case '/':
+		result.Token = token.Divide
+	case '%':
+		result.Token = token.Modulo
 	case '(':
 		result.Token = token.OpenParenthesis
 	case ')':
@@ -114,9 +168,92 @@ func (s *Scanner) Scan() (result TokenText) {
 		result.Token = token.CloseSquareBracket
 	case ',':
 		result.Token = token.Comma
+	case '.':
+		result.Token = token.Dot
 	default:
 		result.Token = token.Illegal
 	}
 
 	return
 }
+
+// unquote return string token with unquoted text
+// or illegal token with raw text if text is not valid
+func (s *Scanner) unquote(result TokenText, fn func(string) (string, error)) TokenText {
+	if s.err != nil {
+		result.Token = token.Illegal
+		result.Err = s.err
+		return result
+	}
+
+	value, err := fn(result.Text)
+	if err != nil {
+		result.Token = token.Illegal
+		result.Err = err
+		return result
+	}
+
+	result.Token = token.String
+	result.Text = value
+	return result
+}
+
+// scanSingleQuoted consume rest of 'abc' after first ' and append it to text
+func (s *Scanner) scanSingleQuoted(result *TokenText) error {
+	var sb strings.Builder
+	sb.WriteString(result.Text)
+	defer func() {
+		result.Text = sb.String()
+	}()
+
+	for {
+		ch := s.textScanner.Next()
+		switch ch {
+		case scanner.EOF:
+			return errNotTerminated
+		case '\n':
+			sb.WriteRune(ch)
+			return errNotTerminated
+		case '\'':
+			sb.WriteRune(ch)
+			return nil
+		case '\\':
+			sb.WriteRune(ch)
+
+			// escaped character is never end of string
+			ch = s.textScanner.Next()
+			if ch == scanner.EOF {
+				return errNotTerminated
+			}
+		}
+
+		sb.WriteRune(ch)
+	}
+}
+
+// unquoteSingle is strconv.Unquote for 'abc' which has more than one character
+func unquoteSingle(text string) (string, error) {
+	if len(text) < 2 || text[0] != '\'' || text[len(text)-1] != '\'' {
+		return "", strconv.ErrSyntax
+	}
+
+	text = text[1 : len(text)-1]
+
+	var sb strings.Builder
+	for len(text) > 0 {
+		value, multibyte, tail, err := strconv.UnquoteChar(text, '\'')
+		if err != nil {
+			return "", err
+		}
+
+		if multibyte {
+			sb.WriteRune(value)
+		} else {
+			sb.WriteByte(byte(value))
+		}
+
+		text = tail
+	}
+
+	return sb.String(), nil
+}
@@ -1,8 +1,11 @@
 package scanner
 
 import (
+	"errors"
+	"strconv"
 	"strings"
 	"testing"
+	"text/scanner"
 
 	"github.com/haunt98/evaluator/token"
 	"github.com/stretchr/testify/assert"
@@ -40,6 +43,14 @@ func generateTestCaseLiteral() []scannerTestCase {
 				Text:  "false",
 			},
 		},
+		{
+			name:  "null",
+			input: "NULL",
+			want: TokenText{
+				Token: token.Null,
+				Text:  "null",
+			},
+		},
 		{
 			name:  "int",
 			input: "1",
@@ -48,6 +59,30 @@ func generateTestCaseLiteral() []scannerTestCase {
 				Text:  "1",
 			},
 		},
+		{
+			name:  "float",
+			input: "1.5",
+			want: TokenText{
+				Token: token.Float,
+				Text:  "1.5",
+			},
+		},
+		{
+			name:  "float exponent",
+			input: "1e3",
+			want: TokenText{
+				Token: token.Float,
+				Text:  "1e3",
+			},
+		},
+		{
+			name:  "float decimal exponent",
+			input: "2.5E-3",
+			want: TokenText{
+				Token: token.Float,
+				Text:  "2.5E-3",
+			},
+		},
 		{
 			name:  "string",
 			input: `"a"`,
@@ -56,6 +91,46 @@ func generateTestCaseLiteral() []scannerTestCase {
 				Text:  "a",
 			},
 		},
+		{
+			name:  "string empty",
+			input: `""`,
+			want: TokenText{
+				Token: token.String,
+				Text:  "",
+			},
+		},
+		{
+			name:  "string escape",
+			input: `"\"a\"\n\t\\\u00e9"`,
+			want: TokenText{
+				Token: token.String,
+				Text:  "\"a\"\n\t\\\u00e9",
+			},
+		},
+		{
+			name:  "string single quoted",
+			input: `'it\'s "a"'`,
+			want: TokenText{
+				Token: token.String,
+				Text:  `it's "a"`,
+			},
+		},
+		{
+			name:  "string single quoted escape",
+			input: `'\n\x41é'`,
+			want: TokenText{
+				Token: token.String,
+				Text:  "\nAé",
+			},
+		},
+		{
+			name:  "string raw",
+			input: "`a\\n\"b`",
+			want: TokenText{
+				Token: token.String,
+				Text:  `a\n"b`,
+			},
+		},
 	}
 }
 
@@ -194,6 +269,46 @@ func generateTestCaseOperator() []scannerTestCase {
 				Text:  "notin",
 			},
 		},
+		{
+			name:  "plus",
+			input: "+",
+			want: TokenText{
+				Token: token.Plus,
+				Text:  "+",
+			},
+		},
+		{
+			name:  "minus",
+			input: "-",
+			want: TokenText{
+				Token: token.Minus,
+				Text:  "-",
+			},
+		},
+		{
+			name:  "multiply",
+			input: "*",
+			want: TokenText{
+				Token: token.Multiply,
+				Text:  "*",
+			},
+		},
+		{
+			name:  "divide",
+			input: "/",
+			want: TokenText{
+				Token: token.Divide,
+				Text:  "/",
+			},
+		},
+		{
+			name:  "modulo",
+			input: "%",
+			want: TokenText{
+				Token: token.Modulo,
+				Text:  "%",
+			},
+		},
 	}
 }
 
@@ -281,7 +396,86 @@ func generateTestCaseIllegal() []scannerTestCase {
 			input: "=!",
 			want: TokenText{
 				Token: token.Illegal,
-				Text:  "=!",
+				Text:  "=",
+			},
+		},
+		{
+			name:  "= 1",
+			input: "= 1",
+			want: TokenText{
+				Token: token.Illegal,
+				Text:  "=",
+			},
+		},
+		{
+			name:  "#",
+			input: "#",
+			want: TokenText{
+				Token: token.Illegal,
+				Text:  "#",
+			},
+		},
+		{
+			name:  "string not terminated",
+			input: `"a`,
+			want: TokenText{
+				Token: token.Illegal,
+				Text:  `"a`,
+				Err:   errors.New("literal not terminated"),
+			},
+		},
+		{
+			name:  "string with new line",
+			input: "\"a\nb\"",
+			want: TokenText{
+				Token: token.Illegal,
+				Text:  "\"a\n",
+				Err:   errors.New("literal not terminated"),
+			},
+		},
+		{
+			name:  "string invalid escape",
+			input: `"\q"`,
+			want: TokenText{
+				Token: token.Illegal,
+				Text:  `"\q"`,
+				Err:   errors.New("invalid char escape"),
+			},
+		},
+		{
+			name:  "string invalid unicode",
+			input: `"\ud800"`,
+			want: TokenText{
+				Token: token.Illegal,
+				Text:  `"\ud800"`,
+				Err:   strconv.ErrSyntax,
+			},
+		},
+		{
+			name:  "string single quoted not terminated",
+			input: `'a\'`,
+			want: TokenText{
+				Token: token.Illegal,
+				Text:  `'a\'`,
+				Err:   errors.New("literal not terminated"),
+			},
+		},
+		{
+			name:  "string raw not terminated",
+			input: "`a",
+			want: TokenText{
+				Token: token.Illegal,
+				Text:  "`a",
+				Err:   errors.New("literal not terminated"),
+			},
+		},
+		{
+			name:  "float without exponent",
+			input: "1e",
+			want: TokenText{
+				Token: token.Illegal,
+				Text:  "1e",
+				Err:   errors.New("exponent has no digits"),
 			},
 		},
 	}
@@ -299,7 +493,107 @@ func TestScannerScan(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			s := NewScanner(strings.NewReader(tc.input))
 			got := s.Scan()
+			// position is tested in TestScannerScanPosition
+			got.Pos = scanner.Position{}
 			assert.Equal(t, tc.want, got)
 		})
 	}
 }
+
+func TestScannerScanPosition(t *testing.T) {
+	s := NewScanner(strings.NewReader("$a == (\n  1"))
+
+	wants := []TokenText{
+		{
+			Token: token.Var,
+			Text:  "a",
+			Pos: scanner.Position{
+				Offset: 0,
+				Line:   1,
+				Column: 1,
+			},
+		},
+		{
+			Token: token.Equal,
+			Text:  "==",
+			Pos: scanner.Position{
+				Offset: 3,
+				Line:   1,
+				Column: 4,
+			},
+		},
+		{
+			Token: token.OpenParenthesis,
+			Text:  "(",
+			Pos: scanner.Position{
+				Offset: 6,
+				Line:   1,
+				Column: 7,
+			},
+		},
+		{
+			Token: token.Int,
+			Text:  "1",
+			Pos: scanner.Position{
+				Offset: 10,
+				Line:   2,
+				Column: 3,
+			},
+		},
+		{
+			Token: token.EOF,
+			Text:  "",
+			Pos: scanner.Position{
+				Offset: 11,
+				Line:   2,
+				Column: 4,
+			},
+		},
+	}
+
+	for _, want := range wants {
+		got := s.Scan()
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestScannerScanAfterIllegal(t *testing.T) {
+	s := NewScanner(strings.NewReader("$a == 'b\n\"c\""))
+
+	wants := []TokenText{
+		{
+			Token: token.Var,
+			Text:  "a",
+		},
+		{
+			Token: token.Equal,
+			Text:  "==",
+		},
+		{
+			Token: token.Illegal,
+			Text:  "'b\n",
+			Err:   errors.New("literal not terminated"),
+			Pos: scanner.Position{
+				Offset: 6,
+				Line:   1,
+				Column: 7,
+			},
+		},
+		{
+			Token: token.String,
+			Text:  "c",
+		},
+		{
+			Token: token.EOF,
+		},
+	}
+
+	for _, want := range wants {
+		got := s.Scan()
+		// only position of illegal token is tested
+		if want.Token != token.Illegal {
+			got.Pos = scanner.Position{}
+		}
+		assert.Equal(t, want, got)
+	}
+}
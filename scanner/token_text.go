@@ -2,13 +2,18 @@ package scanner
 
 import (
 	"fmt"
+	"text/scanner"
 
 	"github.com/haunt98/evaluator/token"
 )
 
+// Pos is start position of token in input
+// Err is why token is illegal, such as unterminated string
 type TokenText struct {
 	Token token.Token
 	Text  string
+	Pos   scanner.Position
+	Err   error
 }
 
 func (tokText TokenText) String() string {
@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error is invalid value of single var
+// Path is var name, with field or index if value is nested
+type Error struct {
+	Path string
+	Msg  string
+}
+
+func newError(path string, format string, a ...interface{}) *Error {
+	return &Error{
+		Path: path,
+		Msg:  fmt.Sprintf(format, a...),
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Path + ": " + e.Msg
+}
+
+// Errors is all invalid values of args
+type Errors []*Error
+
+func (errs Errors) Error() string {
+	represents := make([]string, len(errs))
+	for i, err := range errs {
+		represents[i] = err.Error()
+	}
+
+	return strings.Join(represents, "; ")
+}
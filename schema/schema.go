@@ -0,0 +1,193 @@
+// Package schema declare vars which expression can use
+//
+// Usage:
+//
+//	s := schema.Schema{
+//		"age":  {Type: typecheck.Int, Required: true},
+//		"tags": {Type: typecheck.ArrayOf(typecheck.String)},
+//	}
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/internal/access"
+	"github.com/haunt98/evaluator/typecheck"
+)
+
+// Variable is declaration of single var
+// Required var must be in args when evaluate
+// Var which is not required can be nil, it is null when evaluate
+type Variable struct {
+	Type     *typecheck.Type
+	Required bool
+}
+
+// Schema is declaration of all vars by name
+type Schema map[string]Variable
+
+// Types return type of each var to use with typecheck
+func (s Schema) Types() map[string]*typecheck.Type {
+	types := make(map[string]*typecheck.Type, len(s))
+	for name, variable := range s {
+		types[name] = variable.typ()
+	}
+
+	return types
+}
+
+// Check reject expression which use undeclared vars or has type mismatches
+func (s Schema) Check(expr expression.Expression, opts ...typecheck.Option) error {
+	_, err := typecheck.Check(expr, s.Types(), opts...)
+	return err
+}
+
+// Validate report all missing required vars and vars with wrong type in args
+// Args which are not declared are ignored
+func (s Schema) Validate(args map[string]interface{}) error {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs Errors
+	for _, name := range names {
+		variable := s[name]
+
+		value, ok := args[name]
+		if !ok {
+			if variable.Required {
+				errs = append(errs, newError(name, "missing required var"))
+			}
+
+			continue
+		}
+
+		if !variable.Required && isNil(reflect.ValueOf(value)) {
+			continue
+		}
+
+		errs = append(errs, validate(name, variable.typ(), reflect.ValueOf(value))...)
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (variable Variable) typ() *typecheck.Type {
+	if variable.Type == nil {
+		return typecheck.Any
+	}
+
+	return variable.Type
+}
+
+// validate check go value belong to type
+// int value belong to float type because int can be used as float
+func validate(path string, t *typecheck.Type, rv reflect.Value) Errors {
+	if t.Kind == typecheck.AnyKind {
+		return nil
+	}
+
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return Errors{newError(path, "expect %s got nil", t)}
+		}
+
+		rv = rv.Elem()
+	}
+
+	if !rv.IsValid() {
+		return Errors{newError(path, "expect %s got nil", t)}
+	}
+
+	mismatch := Errors{newError(path, "expect %s got %s", t, rv.Type())}
+
+	switch t.Kind {
+	case typecheck.BoolKind:
+		if rv.Kind() != reflect.Bool {
+			return mismatch
+		}
+	case typecheck.IntKind:
+		if !isInt(rv.Kind()) {
+			return mismatch
+		}
+	case typecheck.FloatKind:
+		if !isInt(rv.Kind()) && rv.Kind() != reflect.Float32 && rv.Kind() != reflect.Float64 {
+			return mismatch
+		}
+	case typecheck.StringKind:
+		if rv.Kind() != reflect.String {
+			return mismatch
+		}
+	case typecheck.ArrayKind:
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return mismatch
+		}
+
+		if t.Elem == nil {
+			return nil
+		}
+
+		var errs Errors
+		for i := 0; i < rv.Len(); i++ {
+			errs = append(errs, validate(fmt.Sprintf("%s[%d]", path, i), t.Elem, rv.Index(i))...)
+		}
+
+		return errs
+	case typecheck.ObjectKind:
+		if !access.HasFields(rv) {
+			return mismatch
+		}
+
+		names := make([]string, 0, len(t.Fields))
+		for name := range t.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var errs Errors
+		for _, name := range names {
+			value, ok := access.Field(rv, name)
+			if !ok {
+				continue
+			}
+
+			errs = append(errs, validate(path+"."+name, t.Fields[name], value)...)
+		}
+
+		return errs
+	}
+
+	return nil
+}
+
+// isNil return true if rv is nil, nil pointer or nil interface
+func isNil(rv reflect.Value) bool {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return true
+		}
+
+		rv = rv.Elem()
+	}
+
+	return !rv.IsValid()
+}
+
+func isInt(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,154 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/haunt98/evaluator/parser"
+	"github.com/haunt98/evaluator/typecheck"
+	"github.com/stretchr/testify/assert"
+)
+
+type profile struct {
+	Country string `evaluator:"country"`
+}
+
+var s = Schema{
+	"age": {
+		Type:     typecheck.Int,
+		Required: true,
+	},
+	"score": {
+		Type: typecheck.Float,
+	},
+	"tags": {
+		Type: typecheck.ArrayOf(typecheck.String),
+	},
+	"profile": {
+		Type: typecheck.ObjectOf(map[string]*typecheck.Type{
+			"country": typecheck.String,
+		}),
+	},
+	"payload": {},
+}
+
+func TestSchemaValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputArgs map[string]interface{}
+		wantErr   string
+	}{
+		{
+			name: "ok",
+			inputArgs: map[string]interface{}{
+				"age":     18,
+				"score":   0.5,
+				"tags":    []string{"a"},
+				"profile": map[string]interface{}{"country": "vn"},
+				"payload": struct{}{},
+				"other":   1,
+			},
+		},
+		{
+			name: "ok struct",
+			inputArgs: map[string]interface{}{
+				"age":     int32(18),
+				"score":   1,
+				"profile": &profile{Country: "vn"},
+			},
+		},
+		{
+			name:      "missing required",
+			inputArgs: map[string]interface{}{},
+			wantErr:   "age: missing required var",
+		},
+		{
+			name: "wrong type",
+			inputArgs: map[string]interface{}{
+				"age":   "18",
+				"score": "0.5",
+			},
+			wantErr: "age: expect int got string; score: expect float got string",
+		},
+		{
+			name: "wrong nested type",
+			inputArgs: map[string]interface{}{
+				"age":     18,
+				"tags":    []interface{}{"a", 1},
+				"profile": map[string]interface{}{"country": 1},
+			},
+			wantErr: "profile.country: expect string got int; tags[1]: expect string got int",
+		},
+		{
+			name: "wrong object",
+			inputArgs: map[string]interface{}{
+				"age":     18,
+				"profile": "vn",
+			},
+			wantErr: "profile: expect object{country string} got string",
+		},
+		{
+			name: "nil",
+			inputArgs: map[string]interface{}{
+				"age": nil,
+			},
+			wantErr: "age: expect int got nil",
+		},
+		{
+			name: "nil optional",
+			inputArgs: map[string]interface{}{
+				"age":     18,
+				"score":   nil,
+				"profile": (*profile)(nil),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotErr := s.Validate(tc.inputArgs)
+			if tc.wantErr != "" {
+				assert.EqualError(t, gotErr, tc.wantErr)
+				return
+			}
+			assert.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestSchemaCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{
+			name:  "ok",
+			input: `$age > 18 and $profile.country == "vn" and $payload.x`,
+		},
+		{
+			name:    "undeclared var",
+			input:   "$x > 1",
+			wantErr: true,
+		},
+		{
+			name:    "type mismatch",
+			input:   `$age == "ten"`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := parser.NewParser(tc.input)
+			expr, err := p.Parse()
+			assert.NoError(t, err)
+
+			gotErr := s.Check(expr)
+			if tc.wantErr {
+				assert.Error(t, gotErr)
+				return
+			}
+			assert.NoError(t, gotErr)
+		})
+	}
+}
@@ -1,5 +1,9 @@
 package token
 
+import (
+	"fmt"
+)
+
 type Token int
 
 const (
@@ -9,7 +13,9 @@ const (
 	Ident
 	Bool
 	Int
+	Float
 	String
+	Null
 	Var
 
 	Or
@@ -24,11 +30,18 @@ const (
 	NotIn
 	Not
 
+	Plus
+	Minus
+	Multiply
+	Divide
+	Modulo
+
 	OpenParenthesis
 	CloseParenthesis
 	OpenSquareBracket
 	CloseSquareBracket
 	Comma
+	Dot
 )
 
 const (
@@ -37,6 +50,10 @@ const (
 	secondLevel
 	thirdLevel
 	fourthLevel
+	fifthLevel
+	// PrefixLevel is used to parse operand of prefix operator
+	PrefixLevel
+	postfixLevel
 )
 
 var (
@@ -46,7 +63,9 @@ var (
 		Ident:              "Ident",
 		Bool:               "Bool",
 		Int:                "Int",
+		Float:              "Float",
 		String:             "String",
+		Null:               "Null",
 		Var:                "Var",
 		Or:                 "Or",
 		And:                "And",
@@ -59,26 +78,74 @@ var (
 		In:                 "In",
 		NotIn:              "NotIn",
 		Not:                "!",
+		Plus:               "+",
+		Minus:              "-",
+		Multiply:           "*",
+		Divide:             "/",
+		Modulo:             "%",
 		OpenParenthesis:    "(",
 		CloseParenthesis:   ")",
 		OpenSquareBracket:  "[",
 		CloseSquareBracket: "]",
 		Comma:              ",",
+		Dot:                ".",
+	}
+
+	// names is used to encode token as text, it is the same as constant name
+	names = map[Token]string{
+		Illegal:            "Illegal",
+		EOF:                "EOF",
+		Ident:              "Ident",
+		Bool:               "Bool",
+		Int:                "Int",
+		Float:              "Float",
+		String:             "String",
+		Null:               "Null",
+		Var:                "Var",
+		Or:                 "Or",
+		And:                "And",
+		Equal:              "Equal",
+		NotEqual:           "NotEqual",
+		Less:               "Less",
+		LessOrEqual:        "LessOrEqual",
+		Greater:            "Greater",
+		GreaterOrEqual:     "GreaterOrEqual",
+		In:                 "In",
+		NotIn:              "NotIn",
+		Not:                "Not",
+		Plus:               "Plus",
+		Minus:              "Minus",
+		Multiply:           "Multiply",
+		Divide:             "Divide",
+		Modulo:             "Modulo",
+		OpenParenthesis:    "OpenParenthesis",
+		CloseParenthesis:   "CloseParenthesis",
+		OpenSquareBracket:  "OpenSquareBracket",
+		CloseSquareBracket: "CloseSquareBracket",
+		Comma:              "Comma",
+		Dot:                "Dot",
 	}
 
 	// https://en.wikipedia.org/wiki/Order_of_operations
 	precedences = map[Token]int{
-		Or:             firstLevel,
-		And:            secondLevel,
-		Equal:          thirdLevel,
-		NotEqual:       thirdLevel,
-		Less:           thirdLevel,
-		LessOrEqual:    thirdLevel,
-		Greater:        thirdLevel,
-		GreaterOrEqual: thirdLevel,
-		In:             thirdLevel,
-		NotIn:          thirdLevel,
-		Not:            fourthLevel,
+		Or:                firstLevel,
+		And:               secondLevel,
+		Equal:             thirdLevel,
+		NotEqual:          thirdLevel,
+		Less:              thirdLevel,
+		LessOrEqual:       thirdLevel,
+		Greater:           thirdLevel,
+		GreaterOrEqual:    thirdLevel,
+		In:                thirdLevel,
+		NotIn:             thirdLevel,
+		Plus:              fourthLevel,
+		Minus:             fourthLevel,
+		Multiply:          fifthLevel,
+		Divide:            fifthLevel,
+		Modulo:            fifthLevel,
+		Not:               PrefixLevel,
+		Dot:               postfixLevel,
+		OpenSquareBracket: postfixLevel,
 	}
 )
 
@@ -99,3 +166,35 @@ func (tok Token) Precedence() int {
 
 	return precedence
 }
+
+// IsWord return true if token is ident or keyword
+// Word can be field name such as $a.in
+func (tok Token) IsWord() bool {
+	switch tok {
+	case Ident, Bool, Null, Or, And, In, NotIn:
+		return true
+	default:
+		return false
+	}
+}
+
+// MarshalText encode token by name so it does not depend on order of constants
+func (tok Token) MarshalText() ([]byte, error) {
+	name, ok := names[tok]
+	if !ok {
+		return nil, fmt.Errorf("not implement token %d", int(tok))
+	}
+
+	return []byte(name), nil
+}
+
+func (tok *Token) UnmarshalText(text []byte) error {
+	for t, name := range names {
+		if name == string(text) {
+			*tok = t
+			return nil
+		}
+	}
+
+	return fmt.Errorf("not implement token %s", text)
+}
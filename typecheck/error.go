@@ -0,0 +1,37 @@
+package typecheck
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/haunt98/evaluator/expression"
+)
+
+// Error is type mismatch of single expression
+type Error struct {
+	Expr expression.Expression
+	Msg  string
+}
+
+func newError(expr expression.Expression, format string, a ...interface{}) *Error {
+	return &Error{
+		Expr: expr,
+		Msg:  fmt.Sprintf(format, a...),
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Msg + " in " + e.Expr.String()
+}
+
+// Errors is all type mismatches of expression
+type Errors []*Error
+
+func (errs Errors) Error() string {
+	represents := make([]string, len(errs))
+	for i, err := range errs {
+		represents[i] = err.Error()
+	}
+
+	return strings.Join(represents, "; ")
+}
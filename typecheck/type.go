@@ -0,0 +1,146 @@
+package typecheck
+
+import (
+	"sort"
+	"strings"
+)
+
+type Kind int
+
+const (
+	// AnyKind is unknown kind, which is not checked
+	AnyKind Kind = iota
+	BoolKind
+	IntKind
+	FloatKind
+	StringKind
+	ArrayKind
+	ObjectKind
+)
+
+var kindRepresents = map[Kind]string{
+	AnyKind:    "any",
+	BoolKind:   "bool",
+	IntKind:    "int",
+	FloatKind:  "float",
+	StringKind: "string",
+	ArrayKind:  "array",
+	ObjectKind: "object",
+}
+
+func (k Kind) String() string {
+	represent, ok := kindRepresents[k]
+	if !ok {
+		return "unknown"
+	}
+
+	return represent
+}
+
+// Type is type of expression or var
+// Elem is type of items if Kind is ArrayKind
+// Fields is type of fields if Kind is ObjectKind, nil Fields allow any field
+type Type struct {
+	Kind   Kind
+	Elem   *Type
+	Fields map[string]*Type
+}
+
+var (
+	Any    = &Type{Kind: AnyKind}
+	Bool   = &Type{Kind: BoolKind}
+	Int    = &Type{Kind: IntKind}
+	Float  = &Type{Kind: FloatKind}
+	String = &Type{Kind: StringKind}
+)
+
+func ArrayOf(elem *Type) *Type {
+	return &Type{
+		Kind: ArrayKind,
+		Elem: elem,
+	}
+}
+
+func ObjectOf(fields map[string]*Type) *Type {
+	return &Type{
+		Kind:   ObjectKind,
+		Fields: fields,
+	}
+}
+
+func (t *Type) String() string {
+	switch t.Kind {
+	case ArrayKind:
+		return "[]" + t.elem().String()
+	case ObjectKind:
+		if t.Fields == nil {
+			return t.Kind.String()
+		}
+
+		names := make([]string, 0, len(t.Fields))
+		for name := range t.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fieldsRepresent := make([]string, len(names))
+		for i, name := range names {
+			fieldsRepresent[i] = name + " " + t.Fields[name].String()
+		}
+
+		return t.Kind.String() + "{" + strings.Join(fieldsRepresent, ", ") + "}"
+	default:
+		return t.Kind.String()
+	}
+}
+
+// elem return Any if array item type is missing
+func (t *Type) elem() *Type {
+	if t.Elem == nil {
+		return Any
+	}
+
+	return t.Elem
+}
+
+func (t *Type) isAny() bool {
+	return t.Kind == AnyKind
+}
+
+func (t *Type) isNumber() bool {
+	return t.Kind == IntKind || t.Kind == FloatKind
+}
+
+// canCompare return true if a == b can be evaluated
+func canCompare(a, b *Type) bool {
+	if a.isAny() || b.isAny() {
+		return true
+	}
+
+	if a.isNumber() && b.isNumber() {
+		return true
+	}
+
+	if a.Kind != b.Kind {
+		return false
+	}
+
+	return a.Kind == BoolKind || a.Kind == StringKind
+}
+
+// common return type which both a and b belong to
+func common(a, b *Type) *Type {
+	if a.isAny() || b.isAny() {
+		return Any
+	}
+
+	if a.Kind == b.Kind && a.Kind != ArrayKind && a.Kind != ObjectKind {
+		return a
+	}
+
+	if a.Kind == ArrayKind && b.Kind == ArrayKind {
+		return ArrayOf(common(a.elem(), b.elem()))
+	}
+
+	return Any
+}
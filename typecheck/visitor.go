@@ -0,0 +1,378 @@
+// Package typecheck infer type of expression before evaluate
+// so type mismatches are reported without args
+package typecheck
+
+import (
+	"github.com/haunt98/evaluator/evaluate"
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/token"
+)
+
+var _ expression.Visitor = (*visitor)(nil)
+
+// visitor record type of each expression instead of evaluate it
+// Visit always return the same expression, errors are collected to check all expression
+type visitor struct {
+	vars     map[string]*Type
+	registry *evaluate.Registry
+	types    map[expression.Expression]*Type
+	errs     Errors
+}
+
+type Option func(v *visitor)
+
+// WithRegistry allow expression to call functions in registry
+func WithRegistry(registry *evaluate.Registry) Option {
+	return func(v *visitor) {
+		v.registry = registry
+	}
+}
+
+// NewVisitor return visitor with declared vars
+// Var which is not in vars is reported as undeclared
+func NewVisitor(vars map[string]*Type, opts ...Option) *visitor {
+	v := &visitor{
+		vars:  vars,
+		types: make(map[expression.Expression]*Type),
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Check return type of expression and all type mismatches as Errors
+func Check(expr expression.Expression, vars map[string]*Type, opts ...Option) (*Type, error) {
+	v := NewVisitor(vars, opts...)
+	if _, err := v.Visit(expr); err != nil {
+		return nil, err
+	}
+
+	if len(v.errs) != 0 {
+		return nil, v.errs
+	}
+
+	return v.Type(expr), nil
+}
+
+// Type return inferred type of visited expression
+func (v *visitor) Type(expr expression.Expression) *Type {
+	t, ok := v.types[expr]
+	if !ok {
+		return Any
+	}
+
+	return t
+}
+
+// Errors return all type mismatches of visited expressions
+func (v *visitor) Errors() Errors {
+	return v.errs
+}
+
+func (v *visitor) Visit(expr expression.Expression) (expression.Expression, error) {
+	return expr.Accept(v)
+}
+
+func (v *visitor) VisitLiteral(expr expression.Expression) (expression.Expression, error) {
+	switch e := expr.(type) {
+	case *expression.BoolLiteral:
+		return v.record(expr, Bool)
+	case *expression.IntLiteral:
+		return v.record(expr, Int)
+	case *expression.FloatLiteral:
+		return v.record(expr, Float)
+	case *expression.StringLiteral:
+		return v.record(expr, String)
+	case *expression.NullLiteral:
+		// null can be compared with any type
+		return v.record(expr, Any)
+	case *expression.SetLiteral:
+		elem, err := v.elemOf(e.Children)
+		if err != nil {
+			return nil, err
+		}
+
+		return v.record(expr, ArrayOf(elem))
+	default:
+		return v.record(expr, Any)
+	}
+}
+
+func (v *visitor) VisitVar(expr *expression.VarExpression) (expression.Expression, error) {
+	t, ok := v.vars[expr.Value]
+	if !ok {
+		return v.fail(expr, "undeclared var %s", expr.Value)
+	}
+
+	return v.record(expr, t)
+}
+
+func (v *visitor) VisitArray(expr *expression.ArrayExpression) (expression.Expression, error) {
+	elem, err := v.elemOf(expr.Children)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.record(expr, ArrayOf(elem))
+}
+
+// elemOf return common type of children, it is any if there is no child
+func (v *visitor) elemOf(children []expression.Expression) (*Type, error) {
+	if len(children) == 0 {
+		return Any, nil
+	}
+
+	elem, err := v.typeOf(children[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children[1:] {
+		childType, err := v.typeOf(child)
+		if err != nil {
+			return nil, err
+		}
+
+		elem = common(elem, childType)
+	}
+
+	return elem, nil
+}
+
+func (v *visitor) VisitUnary(expr *expression.UnaryExpression) (expression.Expression, error) {
+	child, err := v.typeOf(expr.Child)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expr.Operator {
+	case token.Not:
+		if !child.isAny() && child.Kind != BoolKind {
+			return v.fail(expr, "expect bool got %s", child)
+		}
+
+		return v.record(expr, Bool)
+	case token.Minus:
+		if !child.isAny() && !child.isNumber() {
+			return v.fail(expr, "expect int or float got %s", child)
+		}
+
+		return v.record(expr, child)
+	default:
+		return v.fail(expr, "not implement unary operator %s", expr.Operator)
+	}
+}
+
+func (v *visitor) VisitBinary(expr *expression.BinaryExpression) (expression.Expression, error) {
+	left, err := v.typeOf(expr.Left)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := v.typeOf(expr.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expr.Operator {
+	case token.Or, token.And:
+		if !left.isAny() && left.Kind != BoolKind {
+			return v.fail(expr, "expect bool got %s", left)
+		}
+
+		if !right.isAny() && right.Kind != BoolKind {
+			return v.fail(expr, "expect bool got %s", right)
+		}
+
+		return v.record(expr, Bool)
+	case token.Equal, token.NotEqual:
+		if !canCompare(left, right) {
+			return v.fail(expr, "can not compare %s with %s", left, right)
+		}
+
+		return v.record(expr, Bool)
+	case token.Less, token.LessOrEqual, token.Greater, token.GreaterOrEqual:
+		if (!left.isAny() && !left.isNumber()) || (!right.isAny() && !right.isNumber()) {
+			return v.fail(expr, "can not order %s with %s", left, right)
+		}
+
+		return v.record(expr, Bool)
+	case token.In, token.NotIn:
+		if right.isAny() {
+			return v.record(expr, Bool)
+		}
+
+		if right.Kind != ArrayKind {
+			return v.fail(expr, "expect array got %s", right)
+		}
+
+		if !canCompare(left, right.elem()) {
+			return v.fail(expr, "can not compare %s with %s", left, right.elem())
+		}
+
+		return v.record(expr, Bool)
+	case token.Plus, token.Minus, token.Multiply, token.Divide, token.Modulo:
+		if (!left.isAny() && !left.isNumber()) || (!right.isAny() && !right.isNumber()) {
+			return v.fail(expr, "expect int or float got %s %s %s", left, expr.Operator, right)
+		}
+
+		if left.Kind == IntKind && right.Kind == IntKind {
+			return v.record(expr, Int)
+		}
+
+		if left.Kind == FloatKind || right.Kind == FloatKind {
+			return v.record(expr, Float)
+		}
+
+		return v.record(expr, Any)
+	default:
+		return v.fail(expr, "not implement binary operator %s", expr.Operator)
+	}
+}
+
+func (v *visitor) VisitCall(expr *expression.CallExpression) (expression.Expression, error) {
+	args := make([]*Type, len(expr.Args))
+	for i, arg := range expr.Args {
+		argType, err := v.typeOf(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		args[i] = argType
+	}
+
+	fn, ok := v.registry.Lookup(expr.Name)
+	if !ok {
+		return v.fail(expr, "not implement function %s", expr.Name)
+	}
+
+	if err := fn.CheckArity(len(args)); err != nil {
+		return v.fail(expr, "%s", err)
+	}
+
+	for i, arg := range args {
+		param := fromKind(fn.Params[len(fn.Params)-1])
+		if i < len(fn.Params) {
+			param = fromKind(fn.Params[i])
+		}
+
+		if param.isAny() || arg.isAny() || param.Kind == arg.Kind {
+			continue
+		}
+
+		if param.Kind == FloatKind && arg.Kind == IntKind {
+			continue
+		}
+
+		return v.fail(expr, "function %s arg %d expect %s got %s", fn.Name, i, param, arg)
+	}
+
+	return v.record(expr, fromKind(fn.Result))
+}
+
+func (v *visitor) VisitMember(expr *expression.MemberExpression) (expression.Expression, error) {
+	object, err := v.typeOf(expr.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	switch object.Kind {
+	case AnyKind:
+		return v.record(expr, Any)
+	case ObjectKind:
+		if object.Fields == nil {
+			return v.record(expr, Any)
+		}
+
+		field, ok := object.Fields[expr.Field]
+		if !ok {
+			return v.fail(expr, "missing field %s", expr.Field)
+		}
+
+		return v.record(expr, field)
+	default:
+		return v.fail(expr, "can not access field %s of %s", expr.Field, object)
+	}
+}
+
+func (v *visitor) VisitIndex(expr *expression.IndexExpression) (expression.Expression, error) {
+	object, err := v.typeOf(expr.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := v.typeOf(expr.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	switch object.Kind {
+	case AnyKind:
+		return v.record(expr, Any)
+	case ArrayKind:
+		if !index.isAny() && index.Kind != IntKind {
+			return v.fail(expr, "expect int index got %s", index)
+		}
+
+		return v.record(expr, object.elem())
+	case ObjectKind:
+		if !index.isAny() && index.Kind != StringKind {
+			return v.fail(expr, "expect string key got %s", index)
+		}
+
+		// known key
+		if keyLit, ok := expr.Index.(*expression.StringLiteral); ok && object.Fields != nil {
+			field, ok := object.Fields[keyLit.Value]
+			if !ok {
+				return v.fail(expr, "missing key %s", keyLit)
+			}
+
+			return v.record(expr, field)
+		}
+
+		return v.record(expr, Any)
+	default:
+		return v.fail(expr, "can not access index of %s", object)
+	}
+}
+
+// typeOf visit expression then return its type
+func (v *visitor) typeOf(expr expression.Expression) (*Type, error) {
+	if _, err := v.Visit(expr); err != nil {
+		return nil, err
+	}
+
+	return v.Type(expr), nil
+}
+
+func (v *visitor) record(expr expression.Expression, t *Type) (expression.Expression, error) {
+	v.types[expr] = t
+	return expr, nil
+}
+
+// fail record error and treat expression as any
+// so parent expression is still checked
+func (v *visitor) fail(expr expression.Expression, format string, a ...interface{}) (expression.Expression, error) {
+	v.errs = append(v.errs, newError(expr, format, a...))
+	return v.record(expr, Any)
+}
+
+func fromKind(kind evaluate.Kind) *Type {
+	switch kind {
+	case evaluate.BoolKind:
+		return Bool
+	case evaluate.IntKind:
+		return Int
+	case evaluate.FloatKind:
+		return Float
+	case evaluate.StringKind:
+		return String
+	case evaluate.ArrayKind:
+		return ArrayOf(Any)
+	default:
+		return Any
+	}
+}
@@ -0,0 +1,252 @@
+package typecheck
+
+import (
+	"testing"
+
+	"github.com/haunt98/evaluator/builtin"
+	"github.com/haunt98/evaluator/evaluate"
+	"github.com/haunt98/evaluator/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCase struct {
+	name     string
+	input    string
+	wantType *Type
+	wantErrs []string
+}
+
+var vars = map[string]*Type{
+	"age":     Int,
+	"score":   Float,
+	"name":    String,
+	"active":  Bool,
+	"tags":    ArrayOf(String),
+	"payload": Any,
+	"user": ObjectOf(map[string]*Type{
+		"profile": ObjectOf(map[string]*Type{
+			"country": String,
+		}),
+		"roles": ArrayOf(String),
+	}),
+	"headers": ObjectOf(nil),
+}
+
+func generateTestCaseOK() []testCase {
+	return []testCase{
+		{
+			name:     "literal",
+			input:    "1",
+			wantType: Int,
+		},
+		{
+			name:     "var",
+			input:    "$score",
+			wantType: Float,
+		},
+		{
+			name:     "null",
+			input:    "$name == null or $age > null",
+			wantType: Bool,
+		},
+		{
+			name:     "array",
+			input:    "[1, 2]",
+			wantType: ArrayOf(Int),
+		},
+		{
+			name:     "array mixed",
+			input:    `[1, "a"]`,
+			wantType: ArrayOf(Any),
+		},
+		{
+			name:     "compare number",
+			input:    "$age > 18 and $score >= 0.5",
+			wantType: Bool,
+		},
+		{
+			name:     "arithmetic int",
+			input:    "$age * 2 + 1",
+			wantType: Int,
+		},
+		{
+			name:     "arithmetic float",
+			input:    "$age * $score",
+			wantType: Float,
+		},
+		{
+			name:     "arithmetic any",
+			input:    "$payload + 1",
+			wantType: Any,
+		},
+		{
+			name:     "in",
+			input:    `"admin" in $tags`,
+			wantType: Bool,
+		},
+		{
+			name:     "member",
+			input:    "$user.profile.country",
+			wantType: String,
+		},
+		{
+			name:     "member any",
+			input:    "$payload.a.b",
+			wantType: Any,
+		},
+		{
+			name:     "index",
+			input:    "$user.roles[0]",
+			wantType: String,
+		},
+		{
+			name:     "index object",
+			input:    `$headers["x-id"]`,
+			wantType: Any,
+		},
+		{
+			name:     "call",
+			input:    `len($tags) > 2 and lower($name) == "vn"`,
+			wantType: Bool,
+		},
+		{
+			name:     "call variadic",
+			input:    "substring($name, 1)",
+			wantType: String,
+		},
+	}
+}
+
+func generateTestCaseMismatch() []testCase {
+	return []testCase{
+		{
+			name:     "undeclared var",
+			input:    "$x",
+			wantErrs: []string{"undeclared var x in Varx"},
+		},
+		{
+			name:     "equal int string",
+			input:    `$age == "ten"`,
+			wantErrs: []string{`can not compare int with string in Varage == "ten"`},
+		},
+		{
+			name:     "less int bool",
+			input:    "1 < true",
+			wantErrs: []string{"can not order int with bool in 1 < true"},
+		},
+		{
+			name:  "report all",
+			input: `($age == "ten" or !$name) and $tags[true] > 1`,
+			wantErrs: []string{
+				`can not compare int with string in Varage == "ten"`,
+				"expect bool got string in !Varname",
+				"expect int index got bool in Vartags[true]",
+			},
+		},
+		{
+			name:     "and int",
+			input:    "$active and 1",
+			wantErrs: []string{"expect bool got int in Varactive And 1"},
+		},
+		{
+			name:     "minus string",
+			input:    "-$name",
+			wantErrs: []string{"expect int or float got string in -Varname"},
+		},
+		{
+			name:     "arithmetic string",
+			input:    `$name + 1`,
+			wantErrs: []string{"expect int or float got string + int in Varname + 1"},
+		},
+		{
+			name:     "in not array",
+			input:    "1 in $age",
+			wantErrs: []string{"expect array got int in 1 In Varage"},
+		},
+		{
+			name:     "in wrong item",
+			input:    "1 in $tags",
+			wantErrs: []string{"can not compare int with string in 1 In Vartags"},
+		},
+		{
+			name:     "missing field",
+			input:    "$user.profile.city",
+			wantErrs: []string{"missing field city in Varuser.profile.city"},
+		},
+		{
+			name:     "field of string",
+			input:    "$name.x",
+			wantErrs: []string{"can not access field x of string in Varname.x"},
+		},
+		{
+			name:     "index string",
+			input:    "$name[0]",
+			wantErrs: []string{"can not access index of string in Varname[0]"},
+		},
+		{
+			name:     "unknown function",
+			input:    "unknown()",
+			wantErrs: []string{"not implement function unknown in unknown()"},
+		},
+		{
+			name:     "call wrong number of args",
+			input:    "lower()",
+			wantErrs: []string{"function lower expect 1 args got 0 in lower()"},
+		},
+		{
+			name:     "call too many optional args",
+			input:    "substring($name, 1, 2, 3)",
+			wantErrs: []string{"function substring expect 2 to 3 args got 4 in substring(Varname, 1, 2, 3)"},
+		},
+		{
+			name:     "call wrong arg",
+			input:    "lower($age)",
+			wantErrs: []string{"function lower arg 0 expect string got int in lower(Varage)"},
+		},
+		{
+			name:     "call wrong result",
+			input:    "len($tags) and true",
+			wantErrs: []string{"expect bool got int in len(Vartags) And true"},
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	registry := evaluate.NewRegistry()
+	err := registry.Register(builtin.Functions()...)
+	assert.NoError(t, err)
+
+	var tests []testCase
+	tests = append(tests, generateTestCaseOK()...)
+	tests = append(tests, generateTestCaseMismatch()...)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := parser.NewParser(tc.input)
+			expr, err := p.Parse()
+			assert.NoError(t, err)
+
+			gotType, gotErr := Check(expr, vars, WithRegistry(registry))
+			if len(tc.wantErrs) != 0 {
+				var gotErrs []string
+				for _, err := range gotErr.(Errors) {
+					gotErrs = append(gotErrs, err.Error())
+				}
+				assert.Equal(t, tc.wantErrs, gotErrs)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tc.wantType, gotType)
+		})
+	}
+}
+
+func TestTypeString(t *testing.T) {
+	assert.Equal(t, "[]int", ArrayOf(Int).String())
+	assert.Equal(t, "[]any", ArrayOf(nil).String())
+	assert.Equal(t, "object", ObjectOf(nil).String())
+	assert.Equal(t, "object{a int, b []string}", ObjectOf(map[string]*Type{
+		"b": ArrayOf(String),
+		"a": Int,
+	}).String())
+}
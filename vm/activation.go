@@ -0,0 +1,133 @@
+package vm
+
+import (
+	"fmt"
+)
+
+// Activation stores vars of program by slot, see Program.Slot
+// Vars are set by typed setters so run does not allocate
+// Activation can be reused after Reset but it is not safe for concurrent use
+type Activation struct {
+	prog   *Program
+	values []value
+	isSet  []bool
+	stack  []value
+}
+
+func (prog *Program) NewActivation() *Activation {
+	return &Activation{
+		prog:   prog,
+		values: make([]value, len(prog.vars)),
+		isSet:  make([]bool, len(prog.vars)),
+		stack:  make([]value, prog.maxStack),
+	}
+}
+
+// Reset unset all vars
+func (act *Activation) Reset() {
+	for i := range act.values {
+		act.values[i] = value{}
+		act.isSet[i] = false
+	}
+}
+
+func (act *Activation) SetBool(slot int, v bool) {
+	act.set(slot, boolValue(v))
+}
+
+func (act *Activation) SetInt(slot int, v int64) {
+	act.set(slot, intValue(v))
+}
+
+func (act *Activation) SetFloat(slot int, v float64) {
+	act.set(slot, floatValue(v))
+}
+
+func (act *Activation) SetString(slot int, v string) {
+	act.set(slot, stringValue(v))
+}
+
+// Set go value which is not bool, int, float, string such as slice, map, struct
+// It is wrapped when used so it may allocate
+func (act *Activation) Set(slot int, v interface{}) {
+	act.set(slot, objectValue(v))
+}
+
+func (act *Activation) set(slot int, v value) {
+	act.values[slot] = v
+	act.isSet[slot] = true
+}
+
+// Run program with vars of activation
+// Return go value, see evaluate.Value for detail
+func (act *Activation) Run() (interface{}, error) {
+	result, err := act.run()
+	if err != nil {
+		return nil, err
+	}
+
+	return result.goValue(), nil
+}
+
+func (act *Activation) RunBool() (bool, error) {
+	result, err := act.run()
+	if err != nil {
+		return false, err
+	}
+
+	if result.kind != boolKind {
+		return false, fmt.Errorf("expect bool got %s", result.expression())
+	}
+
+	return result.b, nil
+}
+
+func (act *Activation) RunInt() (int64, error) {
+	result, err := act.run()
+	if err != nil {
+		return 0, err
+	}
+
+	if result.kind != intKind {
+		return 0, fmt.Errorf("expect int got %s", result.expression())
+	}
+
+	return result.i, nil
+}
+
+func (act *Activation) RunFloat() (float64, error) {
+	result, err := act.run()
+	if err != nil {
+		return 0, err
+	}
+
+	if result.kind != floatKind {
+		return 0, fmt.Errorf("expect float got %s", result.expression())
+	}
+
+	return result.f, nil
+}
+
+func (act *Activation) RunString() (string, error) {
+	result, err := act.run()
+	if err != nil {
+		return "", err
+	}
+
+	if result.kind != stringKind {
+		return "", fmt.Errorf("expect string got %s", result.expression())
+	}
+
+	return result.s, nil
+}
+
+func (act *Activation) run() (value, error) {
+	result, err := act.prog.run(nil, act, act.stack)
+
+	// release references of values
+	for i := range act.stack {
+		act.stack[i] = value{}
+	}
+
+	return result, err
+}
@@ -0,0 +1,161 @@
+package vm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/haunt98/evaluator/evaluate"
+	"github.com/haunt98/evaluator/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func compile(t testing.TB, input string) *Program {
+	expr, err := parser.NewParser(input).Parse()
+	assert.NoError(t, err)
+
+	prog, err := Compile(expr)
+	assert.NoError(t, err)
+
+	return prog
+}
+
+func slot(t testing.TB, prog *Program, name string) int {
+	slot, ok := prog.Slot(name)
+	assert.True(t, ok)
+
+	return slot
+}
+
+func TestActivationRun(t *testing.T) {
+	prog := compile(t, `$x * 2 > $y and $s in ["a", "vn"] and $a`)
+	assert.Equal(t, []string{"x", "y", "s", "a"}, prog.Vars())
+
+	_, ok := prog.Slot("z")
+	assert.False(t, ok)
+
+	act := prog.NewActivation()
+	act.SetInt(slot(t, prog, "x"), 3)
+	act.SetFloat(slot(t, prog, "y"), 5.5)
+	act.SetString(slot(t, prog, "s"), "vn")
+	act.SetBool(slot(t, prog, "a"), true)
+
+	gotResult, gotErr := act.RunBool()
+	assert.NoError(t, gotErr)
+	assert.True(t, gotResult)
+
+	wantResult, wantErr := prog.Run(map[string]interface{}{
+		"x": 3,
+		"y": 5.5,
+		"s": "vn",
+		"a": true,
+	})
+	assert.NoError(t, wantErr)
+	assert.Equal(t, wantResult, gotResult)
+
+	// reuse activation
+	act.SetInt(slot(t, prog, "x"), 2)
+	gotResult, gotErr = act.RunBool()
+	assert.NoError(t, gotErr)
+	assert.False(t, gotResult)
+
+	act.Reset()
+	_, gotErr = act.RunBool()
+	var missingErr *evaluate.MissingVariableError
+	assert.True(t, errors.As(gotErr, &missingErr))
+	assert.Equal(t, "x", missingErr.Name)
+}
+
+func TestActivationRunObject(t *testing.T) {
+	prog := compile(t, `$user.name == $items[0]`)
+
+	act := prog.NewActivation()
+	act.Set(slot(t, prog, "user"), map[string]interface{}{
+		"name": "a",
+	})
+	act.Set(slot(t, prog, "items"), []string{"a"})
+
+	gotResult, gotErr := act.Run()
+	assert.NoError(t, gotErr)
+	assert.Equal(t, true, gotResult)
+}
+
+func TestActivationRunTyped(t *testing.T) {
+	prog := compile(t, "$x + 1")
+
+	act := prog.NewActivation()
+	act.SetInt(slot(t, prog, "x"), 1)
+
+	gotInt, gotErr := act.RunInt()
+	assert.NoError(t, gotErr)
+	assert.Equal(t, int64(2), gotInt)
+
+	_, gotErr = act.RunFloat()
+	assert.Error(t, gotErr)
+
+	act.SetFloat(slot(t, prog, "x"), 0.5)
+
+	gotFloat, gotErr := act.RunFloat()
+	assert.NoError(t, gotErr)
+	assert.Equal(t, 1.5, gotFloat)
+
+	_, gotErr = act.RunBool()
+	assert.Error(t, gotErr)
+}
+
+func TestActivationRunNoAllocs(t *testing.T) {
+	prog := compile(t, benchmarkActivationInput)
+
+	act := prog.NewActivation()
+	act.SetInt(slot(t, prog, "x"), 3)
+	act.SetFloat(slot(t, prog, "y"), 1.5)
+	act.SetString(slot(t, prog, "s"), "vn")
+	act.SetBool(slot(t, prog, "a"), true)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		result, err := act.RunBool()
+		if err != nil || !result {
+			t.Fatal(result, err)
+		}
+	})
+	assert.Equal(t, float64(0), allocs)
+}
+
+const benchmarkActivationInput = `$x * 2 > 5 and $s in ["a", "b", "vn"] and ($y < 1 or -$x < 0 or !$a)`
+
+func BenchmarkActivationRun(b *testing.B) {
+	prog := compile(b, benchmarkActivationInput)
+
+	act := prog.NewActivation()
+	act.SetInt(slot(b, prog, "x"), 3)
+	act.SetFloat(slot(b, prog, "y"), 1.5)
+	act.SetString(slot(b, prog, "s"), "vn")
+	act.SetBool(slot(b, prog, "a"), true)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := act.RunBool(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkActivationVisitor(b *testing.B) {
+	expr, err := parser.NewParser(benchmarkActivationInput).Parse()
+	assert.NoError(b, err)
+
+	args := map[string]interface{}{
+		"x": 3,
+		"y": 1.5,
+		"s": "vn",
+		"a": true,
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := evaluate.NewVisitor(args).Visit(expr); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,385 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/haunt98/evaluator/evaluate"
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/internal/set"
+	"github.com/haunt98/evaluator/token"
+)
+
+var _ expression.Visitor = (*compiler)(nil)
+
+// compiler emit instructions of expression to program
+// Visit always return the same expression
+type compiler struct {
+	prog     *Program
+	registry *evaluate.Registry
+	nameIdxs map[string]int
+	slots    map[string]int
+	// depth is stack size after executing emitted instructions
+	depth int
+}
+
+type Option func(c *compiler)
+
+// WithRegistry allow expression to call functions in registry
+// Registry must not be changed after compile
+func WithRegistry(registry *evaluate.Registry) Option {
+	return func(c *compiler) {
+		c.registry = registry
+	}
+}
+
+// Compile expression to program which can be run many times
+func Compile(expr expression.Expression, opts ...Option) (*Program, error) {
+	c := &compiler{
+		prog:     &Program{},
+		nameIdxs: make(map[string]int),
+		slots:    make(map[string]int),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if _, err := c.Visit(expr); err != nil {
+		return nil, err
+	}
+
+	c.prog.slots = c.slots
+	c.prog.stackPool.New = func() interface{} {
+		stack := make([]value, c.prog.maxStack)
+		return &stack
+	}
+
+	return c.prog, nil
+}
+
+// emit append instruction and return its position
+// delta is change of stack size after executing instruction
+func (c *compiler) emit(node expression.Expression, op Opcode, arg, delta int) int {
+	c.prog.instructions = append(c.prog.instructions, Instruction{
+		Op:  op,
+		Arg: arg,
+	})
+	c.prog.nodes = append(c.prog.nodes, node)
+
+	c.depth += delta
+	if c.depth > c.prog.maxStack {
+		c.prog.maxStack = c.depth
+	}
+
+	return len(c.prog.instructions) - 1
+}
+
+func (c *compiler) emitConst(node expression.Expression, v value) {
+	c.prog.constants = append(c.prog.constants, v)
+	c.emit(node, OpConst, len(c.prog.constants)-1, 1)
+}
+
+// slot return slot of var, same var has same slot
+func (c *compiler) slot(name string) int {
+	if slot, ok := c.slots[name]; ok {
+		return slot
+	}
+
+	c.prog.vars = append(c.prog.vars, name)
+	c.slots[name] = len(c.prog.vars) - 1
+
+	return c.slots[name]
+}
+
+// name return position of field name, same name has same position
+func (c *compiler) name(name string) int {
+	if idx, ok := c.nameIdxs[name]; ok {
+		return idx
+	}
+
+	c.prog.names = append(c.prog.names, name)
+	c.nameIdxs[name] = len(c.prog.names) - 1
+
+	return c.nameIdxs[name]
+}
+
+func (c *compiler) Visit(expr expression.Expression) (expression.Expression, error) {
+	return expr.Accept(c)
+}
+
+func (c *compiler) VisitLiteral(expr expression.Expression) (expression.Expression, error) {
+	// set which is not right of in, notin is array
+	if setLit, ok := expr.(*expression.SetLiteral); ok {
+		return c.VisitArray(expression.NewArrayExpression(setLit.Children...))
+	}
+
+	v, ok := valueOfLiteral(expr)
+	if !ok {
+		return nil, fmt.Errorf("not implement literal %T", expr)
+	}
+
+	c.emitConst(expr, v)
+
+	return expr, nil
+}
+
+func (c *compiler) VisitVar(expr *expression.VarExpression) (expression.Expression, error) {
+	c.emit(expr, OpLoadVar, c.slot(expr.Value), 1)
+
+	return expr, nil
+}
+
+func (c *compiler) VisitArray(expr *expression.ArrayExpression) (expression.Expression, error) {
+	// array of literals is constant
+	if arr, ok := constArray(expr); ok {
+		c.emitConst(expr, arr)
+
+		return expr, nil
+	}
+
+	for _, child := range expr.Children {
+		if _, err := c.Visit(child); err != nil {
+			return nil, err
+		}
+	}
+
+	c.emit(expr, OpArray, len(expr.Children), 1-len(expr.Children))
+
+	return expr, nil
+}
+
+func (c *compiler) VisitUnary(expr *expression.UnaryExpression) (expression.Expression, error) {
+	var op Opcode
+	switch expr.Operator {
+	case token.Not:
+		op = OpNot
+	case token.Minus:
+		op = OpNegate
+	default:
+		c.emit(expr, OpUnsupported, 0, 1)
+
+		return expr, nil
+	}
+
+	if _, err := c.Visit(expr.Child); err != nil {
+		return nil, err
+	}
+
+	c.emit(expr, op, 0, 0)
+
+	return expr, nil
+}
+
+var binaryOpcodes = map[token.Token]Opcode{
+	token.Equal:          OpEqual,
+	token.NotEqual:       OpNotEqual,
+	token.Less:           OpLess,
+	token.LessOrEqual:    OpLessOrEqual,
+	token.Greater:        OpGreater,
+	token.GreaterOrEqual: OpGreaterOrEqual,
+	token.In:             OpIn,
+	token.NotIn:          OpNotIn,
+	token.Plus:           OpAdd,
+	token.Minus:          OpSubtract,
+	token.Multiply:       OpMultiply,
+	token.Divide:         OpDivide,
+	token.Modulo:         OpModulo,
+}
+
+func (c *compiler) VisitBinary(expr *expression.BinaryExpression) (expression.Expression, error) {
+	switch expr.Operator {
+	case token.Or:
+		return expr, c.compileShortCircuit(expr, OpJumpIfTrue)
+	case token.And:
+		return expr, c.compileShortCircuit(expr, OpJumpIfFalse)
+	}
+
+	op, ok := binaryOpcodes[expr.Operator]
+	if !ok {
+		c.emit(expr, OpUnsupported, 0, 1)
+
+		return expr, nil
+	}
+
+	if _, err := c.Visit(expr.Left); err != nil {
+		return nil, err
+	}
+
+	if op == OpIn || op == OpNotIn {
+		if s, ok := c.set(expr.Right); ok {
+			c.prog.sets = append(c.prog.sets, s)
+
+			setOp := OpInSet
+			if op == OpNotIn {
+				setOp = OpNotInSet
+			}
+
+			c.emit(expr, setOp, len(c.prog.sets)-1, 0)
+
+			return expr, nil
+		}
+	}
+
+	if _, err := c.Visit(expr.Right); err != nil {
+		return nil, err
+	}
+
+	c.emit(expr, op, 0, -1)
+
+	return expr, nil
+}
+
+// set return set of right if it is array of literals with many children
+// so in, notin do not compare each child
+func (c *compiler) set(right expression.Expression) (*set.Set, bool) {
+	var arrExpr *expression.ArrayExpression
+	switch r := right.(type) {
+	case *expression.ArrayExpression:
+		arrExpr = r
+	case *expression.SetLiteral:
+		arrExpr = expression.NewArrayExpression(r.Children...)
+	default:
+		return nil, false
+	}
+
+	if len(arrExpr.Children) < set.MinSize {
+		return nil, false
+	}
+
+	arr, ok := constArray(arrExpr)
+	if !ok {
+		return nil, false
+	}
+
+	return newSet(arr.arr), true
+}
+
+// compileShortCircuit jump over right if left decides result
+//
+//	left
+//	JumpIfFalse end
+//	right
+//	AssertBool
+//	end:
+func (c *compiler) compileShortCircuit(expr *expression.BinaryExpression, jumpOp Opcode) error {
+	if _, err := c.Visit(expr.Left); err != nil {
+		return err
+	}
+
+	jump := c.emit(expr, jumpOp, 0, -1)
+
+	if _, err := c.Visit(expr.Right); err != nil {
+		return err
+	}
+
+	c.emit(expr, OpAssertBool, 0, 0)
+
+	// jump to end
+	c.prog.instructions[jump].Arg = len(c.prog.instructions)
+
+	return nil
+}
+
+func (c *compiler) VisitCall(expr *expression.CallExpression) (expression.Expression, error) {
+	fn, ok := c.registry.Lookup(expr.Name)
+	c.prog.functions = append(c.prog.functions, function{
+		name: expr.Name,
+		fn:   fn,
+		ok:   ok,
+		argc: len(expr.Args),
+	})
+	fnIdx := len(c.prog.functions) - 1
+
+	// missing function fails before args are evaluated
+	if !ok {
+		c.emit(expr, OpCall, fnIdx, 1)
+
+		return expr, nil
+	}
+
+	for _, arg := range expr.Args {
+		if _, err := c.Visit(arg); err != nil {
+			return nil, err
+		}
+	}
+
+	c.emit(expr, OpCall, fnIdx, 1-len(expr.Args))
+
+	return expr, nil
+}
+
+func (c *compiler) VisitMember(expr *expression.MemberExpression) (expression.Expression, error) {
+	if err := c.compileObject(expr); err != nil {
+		return nil, err
+	}
+
+	c.emit(expr, OpWrap, 0, 0)
+
+	return expr, nil
+}
+
+func (c *compiler) VisitIndex(expr *expression.IndexExpression) (expression.Expression, error) {
+	if err := c.compileObject(expr); err != nil {
+		return nil, err
+	}
+
+	c.emit(expr, OpWrap, 0, 0)
+
+	return expr, nil
+}
+
+// compileObject push go value of expression without wrap it
+// so map, struct, slice can be accessed later
+func (c *compiler) compileObject(expr expression.Expression) error {
+	switch e := expr.(type) {
+	case *expression.VarExpression:
+		c.emit(e, OpLoadObject, c.slot(e.Value), 1)
+	case *expression.MemberExpression:
+		if err := c.compileObject(e.Object); err != nil {
+			return err
+		}
+
+		c.emit(e, OpMember, c.name(e.Field), 0)
+	case *expression.IndexExpression:
+		if err := c.compileObject(e.Object); err != nil {
+			return err
+		}
+
+		if _, err := c.Visit(e.Index); err != nil {
+			return err
+		}
+
+		c.emit(e, OpIndex, 0, -1)
+	default:
+		if _, err := c.Visit(expr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// constArray return value of array if all children are literals
+func constArray(expr *expression.ArrayExpression) (value, bool) {
+	arr := make([]value, len(expr.Children))
+	for i, child := range expr.Children {
+		switch e := child.(type) {
+		case *expression.ArrayExpression:
+			childValue, ok := constArray(e)
+			if !ok {
+				return value{}, false
+			}
+
+			arr[i] = childValue
+		default:
+			childValue, ok := valueOfLiteral(child)
+			if !ok {
+				return value{}, false
+			}
+
+			arr[i] = childValue
+		}
+	}
+
+	return value{kind: arrayKind, arr: arr}, true
+}
@@ -0,0 +1,104 @@
+package vm
+
+import (
+	"fmt"
+)
+
+type Opcode uint8
+
+const (
+	// OpConst push constant at arg
+	OpConst Opcode = iota
+	// OpLoadVar push var at slot arg
+	OpLoadVar
+	// OpLoadObject push var at slot arg without wrap it
+	// so member, index can access it later
+	OpLoadObject
+	// OpMember pop object, push field with name at arg
+	OpMember
+	// OpIndex pop index and object, push item of object
+	OpIndex
+	// OpWrap pop object, push it as value
+	OpWrap
+	// OpArray pop arg values, push array of them
+	OpArray
+	OpNot
+	OpNegate
+	// OpJumpIfFalse jump to arg if top is false, otherwise pop it
+	OpJumpIfFalse
+	// OpJumpIfTrue jump to arg if top is true, otherwise pop it
+	OpJumpIfTrue
+	// OpAssertBool return error if top is not bool
+	OpAssertBool
+	OpEqual
+	OpNotEqual
+	OpLess
+	OpLessOrEqual
+	OpGreater
+	OpGreaterOrEqual
+	OpIn
+	OpNotIn
+	// OpInSet pop left, push true if left is in set at arg
+	OpInSet
+	// OpNotInSet pop left, push true if left is not in set at arg
+	OpNotInSet
+	OpAdd
+	OpSubtract
+	OpMultiply
+	OpDivide
+	OpModulo
+	// OpCall pop args, push result of function at arg
+	OpCall
+	// OpUnsupported return error of unsupported operator
+	OpUnsupported
+)
+
+var represents = map[Opcode]string{
+	OpConst:          "Const",
+	OpLoadVar:        "LoadVar",
+	OpLoadObject:     "LoadObject",
+	OpMember:         "Member",
+	OpIndex:          "Index",
+	OpWrap:           "Wrap",
+	OpArray:          "Array",
+	OpNot:            "Not",
+	OpNegate:         "Negate",
+	OpJumpIfFalse:    "JumpIfFalse",
+	OpJumpIfTrue:     "JumpIfTrue",
+	OpAssertBool:     "AssertBool",
+	OpEqual:          "Equal",
+	OpNotEqual:       "NotEqual",
+	OpLess:           "Less",
+	OpLessOrEqual:    "LessOrEqual",
+	OpGreater:        "Greater",
+	OpGreaterOrEqual: "GreaterOrEqual",
+	OpIn:             "In",
+	OpNotIn:          "NotIn",
+	OpInSet:          "InSet",
+	OpNotInSet:       "NotInSet",
+	OpAdd:            "Add",
+	OpSubtract:       "Subtract",
+	OpMultiply:       "Multiply",
+	OpDivide:         "Divide",
+	OpModulo:         "Modulo",
+	OpCall:           "Call",
+	OpUnsupported:    "Unsupported",
+}
+
+func (op Opcode) String() string {
+	represent, ok := represents[op]
+	if !ok {
+		return "unknown"
+	}
+
+	return represent
+}
+
+type Instruction struct {
+	Op  Opcode
+	Arg int
+}
+
+func (ins Instruction) String() string {
+	return fmt.Sprintf("%s %d", ins.Op, ins.Arg)
+}
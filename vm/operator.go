@@ -0,0 +1,178 @@
+package vm
+
+import (
+	"errors"
+
+	"github.com/haunt98/evaluator/evaluate"
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/internal/number"
+)
+
+func newMismatchError(node expression.Expression, expected string, got value) *evaluate.TypeMismatchError {
+	return &evaluate.TypeMismatchError{
+		Expr:     node,
+		Expected: expected,
+		Got:      got.expression(),
+	}
+}
+
+func newNumberMismatchError(node expression.Expression, got value) *evaluate.TypeMismatchError {
+	return newMismatchError(node, "int or float literal", got)
+}
+
+func newUnsupportedError(node expression.Expression) *evaluate.UnsupportedOperatorError {
+	switch e := node.(type) {
+	case *expression.UnaryExpression:
+		return &evaluate.UnsupportedOperatorError{
+			Expr:     e,
+			Operator: e.Operator,
+		}
+	case *expression.BinaryExpression:
+		return &evaluate.UnsupportedOperatorError{
+			Expr:     e,
+			Operator: e.Operator,
+		}
+	default:
+		return &evaluate.UnsupportedOperatorError{
+			Expr: node,
+		}
+	}
+}
+
+// equal is the same as visitEqual of evaluate
+func equal(node expression.Expression, left, right value) (bool, error) {
+	result, ok := equalValue(left, right)
+	if ok {
+		return result, nil
+	}
+
+	switch left.kind {
+	case boolKind:
+		return false, newMismatchError(node, "bool literal", right)
+	case intKind, floatKind:
+		return false, newNumberMismatchError(node, right)
+	case stringKind:
+		return false, newMismatchError(node, "string literal", right)
+	default:
+		return false, newMismatchError(node, "bool, int, float or string literal", left)
+	}
+}
+
+// equalValue return false ok if left and right can not be compared
+// It does not allocate error so it is used by in
+func equalValue(left, right value) (result, ok bool) {
+	// null is only equal to null
+	if left.kind == nullKind || right.kind == nullKind {
+		return left.kind == right.kind, true
+	}
+
+	switch left.kind {
+	case boolKind:
+		if right.kind != boolKind {
+			return false, false
+		}
+
+		return left.b == right.b, true
+	case intKind, floatKind:
+		if !right.isNumber() {
+			return false, false
+		}
+
+		if left.kind == intKind && right.kind == intKind {
+			return left.i == right.i, true
+		}
+
+		return left.float() == right.float(), true
+	case stringKind:
+		if right.kind != stringKind {
+			return false, false
+		}
+
+		return left.s == right.s, true
+	default:
+		return false, false
+	}
+}
+
+// compare is the same as compareNumber of evaluate
+func compare(node *expression.BinaryExpression, left, right value) (bool, error) {
+	// null is neither less nor greater than anything
+	if left.kind == nullKind || right.kind == nullKind {
+		return false, nil
+	}
+
+	if !left.isNumber() {
+		return false, newNumberMismatchError(node, left)
+	}
+
+	if !right.isNumber() {
+		return false, newNumberMismatchError(node, right)
+	}
+
+	if left.kind == intKind && right.kind == intKind {
+		return number.CompareInt(node.Operator, left.i, right.i)
+	}
+
+	return number.CompareFloat(node.Operator, left.float(), right.float())
+}
+
+// in compare left to all children of right
+// child with different type is not equal
+func in(node expression.Expression, left, right value) (bool, error) {
+	// null is empty array
+	if right.kind == nullKind {
+		return false, nil
+	}
+
+	if right.kind != arrayKind {
+		return false, newMismatchError(node, "array expression", right)
+	}
+
+	for _, child := range right.arr {
+		if result, ok := equalValue(left, child); ok && result {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// arithmetic is the same as arithmeticNumber of evaluate
+func arithmetic(node *expression.BinaryExpression, left, right value) (value, error) {
+	if !left.isNumber() {
+		return value{}, newNumberMismatchError(node, left)
+	}
+
+	if !right.isNumber() {
+		return value{}, newNumberMismatchError(node, right)
+	}
+
+	var result value
+	var err error
+	if left.kind == intKind && right.kind == intKind {
+		var i int64
+		i, err = number.ArithmeticInt(node.Operator, left.i, right.i)
+		result = intValue(i)
+	} else {
+		var f float64
+		f, err = number.ArithmeticFloat(node.Operator, left.float(), right.float())
+		result = floatValue(f)
+	}
+
+	switch {
+	case errors.Is(err, number.ErrDivisionByZero):
+		return value{}, &evaluate.DivisionByZeroError{
+			Expr:  node,
+			Left:  left.expression(),
+			Right: right.expression(),
+		}
+	case errors.Is(err, number.ErrOverflow):
+		return value{}, &evaluate.OverflowError{
+			Expr:     node,
+			Operator: node.Operator,
+			Operands: []expression.Expression{left.expression(), right.expression()},
+		}
+	}
+
+	return result, err
+}
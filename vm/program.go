@@ -0,0 +1,87 @@
+// Package vm compile expression to instructions and run them on stack machine
+// Result is the same as evaluate visitor but faster because it does not allocate literal of each expression
+package vm
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/haunt98/evaluator/evaluate"
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/internal/set"
+)
+
+// Program is compiled expression
+// Program is immutable so it is safe to run concurrently
+type Program struct {
+	instructions []Instruction
+	// nodes[i] is expression of instructions[i], only used to return error
+	nodes     []expression.Expression
+	constants []value
+	// vars[slot] is name of var
+	vars  []string
+	slots map[string]int
+	// names of field
+	names     []string
+	functions []function
+	sets      []*set.Set
+	maxStack  int
+	// reuse stack between runs
+	stackPool sync.Pool
+}
+
+// function is looked up when compile
+type function struct {
+	name string
+	fn   evaluate.Function
+	ok   bool
+	argc int
+}
+
+// Run program with args
+// Return go value, see evaluate.Value for detail
+func (prog *Program) Run(args map[string]interface{}) (interface{}, error) {
+	stack := prog.stackPool.Get().(*[]value)
+	defer prog.putStack(stack)
+
+	result, err := prog.run(args, nil, *stack)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.goValue(), nil
+}
+
+func (prog *Program) putStack(stack *[]value) {
+	// release references of values
+	for i := range *stack {
+		(*stack)[i] = value{}
+	}
+
+	prog.stackPool.Put(stack)
+}
+
+// Slot return slot of var which is used to set var of activation
+func (prog *Program) Slot(name string) (int, bool) {
+	slot, ok := prog.slots[name]
+	return slot, ok
+}
+
+// Vars return name of vars, index is slot
+func (prog *Program) Vars() []string {
+	vars := make([]string, len(prog.vars))
+	copy(vars, prog.vars)
+
+	return vars
+}
+
+// String return instructions, one per line
+func (prog *Program) String() string {
+	var sb strings.Builder
+	for i, ins := range prog.instructions {
+		fmt.Fprintf(&sb, "%d: %s\n", i, ins)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,222 @@
+package vm
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/haunt98/evaluator/evaluate"
+	"github.com/haunt98/evaluator/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	Name    string `evaluator:"name"`
+	Roles   []string
+	Profile *profile
+}
+
+type profile struct {
+	Country string `evaluator:"country"`
+}
+
+func newArgs() map[string]interface{} {
+	return map[string]interface{}{
+		"a":     true,
+		"b":     false,
+		"x":     3,
+		"y":     1.5,
+		"s":     "vn",
+		"items": []int{1, 2, 3},
+		"headers": map[string]string{
+			"x-id": "1",
+		},
+		"user": &user{
+			Name:  "a",
+			Roles: []string{"admin"},
+			Profile: &profile{
+				Country: "vn",
+			},
+		},
+	}
+}
+
+func TestProgramRun(t *testing.T) {
+	registry := evaluate.NewRegistry()
+	err := registry.Register(evaluate.Function{
+		Name:   "lower",
+		Params: []evaluate.Kind{evaluate.StringKind},
+		Result: evaluate.StringKind,
+		Fn: func(args ...interface{}) (interface{}, error) {
+			return strings.ToLower(args[0].(string)), nil
+		},
+	})
+	assert.NoError(t, err)
+
+	tests := []string{
+		"true",
+		"$a and $b",
+		"$b and $x",
+		"$a or $x",
+		"$a and $x",
+		"$b or !$a",
+		"!$x",
+		"-$x + 1",
+		"-$s",
+		"$x * 2 - 1 > $y",
+		"$x / 0",
+		"$y % 0",
+		"$x / 2 == 1",
+		"$y / 2",
+		"$x != 3.0",
+		"$x == $s",
+		"[1, 2] == 1",
+		"$x in [1, 2, 3]",
+		"$x notin [1, $y, $s]",
+		"$x in $items",
+		"$x in 1",
+		"[$x, $y, [1, $s]]",
+		`lower("VN") == $s`,
+		"lower($x)",
+		"upper($x)",
+		"$missing or true",
+		`$user.name == "a" and "admin" in $user.Roles`,
+		"$user.profile.country",
+		"$user.Profile.country",
+		"$user.age",
+		"$items[1] + $items[2]",
+		"$items[3]",
+		"$items[$s]",
+		`$headers["x-id"]`,
+		`$headers[1]`,
+		"[1, 2, 3][1]",
+		"$user",
+	}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			expr, err := parser.NewParser(input).Parse()
+			assert.NoError(t, err)
+
+			v := evaluate.NewVisitor(newArgs(), evaluate.WithRegistry(registry))
+
+			var wantResult interface{}
+			visitResult, wantErr := v.Visit(expr)
+			if wantErr == nil {
+				wantResult, wantErr = evaluate.Value(visitResult)
+			}
+
+			prog, err := Compile(expr, WithRegistry(registry))
+			assert.NoError(t, err)
+
+			gotResult, gotErr := prog.Run(newArgs())
+			assert.Equal(t, wantErr, gotErr)
+			assert.Equal(t, wantResult, gotResult)
+		})
+	}
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{
+			input: "$a and $b or $c",
+			want: "0: LoadVar 0\n" +
+				"1: JumpIfFalse 4\n" +
+				"2: LoadVar 1\n" +
+				"3: AssertBool 0\n" +
+				"4: JumpIfTrue 7\n" +
+				"5: LoadVar 2\n" +
+				"6: AssertBool 0\n",
+		},
+		{
+			input: "$x in [1, 2]",
+			want: "0: LoadVar 0\n" +
+				"1: Const 0\n" +
+				"2: In 0\n",
+		},
+		{
+			input: "$user.name == $x[0]",
+			want: "0: LoadObject 0\n" +
+				"1: Member 0\n" +
+				"2: Wrap 0\n" +
+				"3: LoadObject 1\n" +
+				"4: Const 0\n" +
+				"5: Index 0\n" +
+				"6: Wrap 0\n" +
+				"7: Equal 0\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			expr, err := parser.NewParser(tc.input).Parse()
+			assert.NoError(t, err)
+
+			prog, err := Compile(expr)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, prog.String())
+		})
+	}
+}
+
+func TestProgramRunConcurrent(t *testing.T) {
+	expr, err := parser.NewParser("$x * 2 in [2, 4, 6] and $a").Parse()
+	assert.NoError(t, err)
+
+	prog, err := Compile(expr)
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(x int) {
+			defer wg.Done()
+
+			gotResult, gotErr := prog.Run(map[string]interface{}{
+				"x": x,
+				"a": true,
+			})
+			assert.NoError(t, gotErr)
+			assert.Equal(t, x >= 1 && x <= 3, gotResult)
+		}(i)
+	}
+	wg.Wait()
+}
+
+const benchmarkInput = `$x * 2 > 5 and $s in ["a", "b", "vn"] and ($y < 1 or $user.Profile.country == "vn")`
+
+func BenchmarkVisitor(b *testing.B) {
+	expr, err := parser.NewParser(benchmarkInput).Parse()
+	assert.NoError(b, err)
+
+	args := newArgs()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := evaluate.NewVisitor(args).Visit(expr); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkProgramRun(b *testing.B) {
+	expr, err := parser.NewParser(benchmarkInput).Parse()
+	assert.NoError(b, err)
+
+	prog, err := Compile(expr)
+	assert.NoError(b, err)
+
+	args := newArgs()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := prog.Run(args); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
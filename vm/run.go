@@ -0,0 +1,236 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/haunt98/evaluator/evaluate"
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/internal/access"
+	"github.com/haunt98/evaluator/internal/number"
+	"github.com/haunt98/evaluator/token"
+)
+
+// run use act if it is not nil, otherwise use args
+func (prog *Program) run(args map[string]interface{}, act *Activation, stack []value) (value, error) {
+	sp := 0
+
+	for pc := 0; pc < len(prog.instructions); pc++ {
+		ins := prog.instructions[pc]
+
+		switch ins.Op {
+		case OpConst:
+			stack[sp] = prog.constants[ins.Arg]
+			sp++
+		case OpLoadVar:
+			v, err := prog.load(pc, args, act)
+			if err != nil {
+				return value{}, err
+			}
+
+			if v.kind == objectKind {
+				if v, err = valueOf(v.object); err != nil {
+					return value{}, err
+				}
+			}
+
+			stack[sp] = v
+			sp++
+		case OpLoadObject:
+			v, err := prog.load(pc, args, act)
+			if err != nil {
+				return value{}, err
+			}
+
+			stack[sp] = v
+			sp++
+		case OpMember:
+			object, err := access.Member(prog.nodes[pc].(*expression.MemberExpression), stack[sp-1].goValue())
+			if err != nil {
+				return value{}, err
+			}
+
+			stack[sp-1] = objectValue(object)
+		case OpIndex:
+			object, err := access.Index(prog.nodes[pc].(*expression.IndexExpression), stack[sp-2].goValue(), stack[sp-1].expression())
+			if err != nil {
+				return value{}, err
+			}
+
+			sp--
+			stack[sp-1] = objectValue(object)
+		case OpWrap:
+			v, err := valueOf(stack[sp-1].object)
+			if err != nil {
+				return value{}, err
+			}
+
+			stack[sp-1] = v
+		case OpArray:
+			arr := make([]value, ins.Arg)
+			copy(arr, stack[sp-ins.Arg:sp])
+			sp -= ins.Arg
+			stack[sp] = value{kind: arrayKind, arr: arr}
+			sp++
+		case OpNot:
+			if stack[sp-1].kind != boolKind {
+				return value{}, newMismatchError(prog.nodes[pc], "bool literal", stack[sp-1])
+			}
+
+			stack[sp-1].b = !stack[sp-1].b
+		case OpNegate:
+			v, err := negate(prog.nodes[pc], stack[sp-1])
+			if err != nil {
+				return value{}, err
+			}
+
+			stack[sp-1] = v
+		case OpJumpIfFalse, OpJumpIfTrue:
+			if stack[sp-1].kind != boolKind {
+				return value{}, newMismatchError(prog.nodes[pc], "bool literal", stack[sp-1])
+			}
+
+			// keep top as result
+			if stack[sp-1].b == (ins.Op == OpJumpIfTrue) {
+				pc = ins.Arg - 1
+				continue
+			}
+
+			sp--
+		case OpAssertBool:
+			if stack[sp-1].kind != boolKind {
+				return value{}, newMismatchError(prog.nodes[pc], "bool literal", stack[sp-1])
+			}
+		case OpEqual, OpNotEqual:
+			result, err := equal(prog.nodes[pc], stack[sp-2], stack[sp-1])
+			if err != nil {
+				return value{}, err
+			}
+
+			sp--
+			stack[sp-1] = boolValue(result == (ins.Op == OpEqual))
+		case OpLess, OpLessOrEqual, OpGreater, OpGreaterOrEqual:
+			result, err := compare(prog.nodes[pc].(*expression.BinaryExpression), stack[sp-2], stack[sp-1])
+			if err != nil {
+				return value{}, err
+			}
+
+			sp--
+			stack[sp-1] = boolValue(result)
+		case OpIn, OpNotIn:
+			result, err := in(prog.nodes[pc], stack[sp-2], stack[sp-1])
+			if err != nil {
+				return value{}, err
+			}
+
+			sp--
+			stack[sp-1] = boolValue(result == (ins.Op == OpIn))
+		case OpInSet, OpNotInSet:
+			result := contains(prog.sets[ins.Arg], stack[sp-1])
+			stack[sp-1] = boolValue(result == (ins.Op == OpInSet))
+		case OpAdd, OpSubtract, OpMultiply, OpDivide, OpModulo:
+			result, err := arithmetic(prog.nodes[pc].(*expression.BinaryExpression), stack[sp-2], stack[sp-1])
+			if err != nil {
+				return value{}, err
+			}
+
+			sp--
+			stack[sp-1] = result
+		case OpCall:
+			fn := prog.functions[ins.Arg]
+			if !fn.ok {
+				return value{}, &evaluate.UnknownFunctionError{
+					Expr: prog.nodes[pc].(*expression.CallExpression),
+					Name: fn.name,
+				}
+			}
+
+			result, err := call(fn, stack[sp-fn.argc:sp])
+			if err != nil {
+				return value{}, err
+			}
+
+			sp -= fn.argc
+			stack[sp] = result
+			sp++
+		case OpUnsupported:
+			return value{}, newUnsupportedError(prog.nodes[pc])
+		default:
+			return value{}, fmt.Errorf("not implement opcode %s", ins.Op)
+		}
+	}
+
+	return stack[sp-1], nil
+}
+
+// load return var at slot of instruction
+// go value which is not wrapped yet is returned as object
+func (prog *Program) load(pc int, args map[string]interface{}, act *Activation) (value, error) {
+	slot := prog.instructions[pc].Arg
+
+	var v value
+	var ok bool
+	if act != nil {
+		v, ok = act.values[slot], act.isSet[slot]
+	} else {
+		var object interface{}
+		object, ok = args[prog.vars[slot]]
+		v = objectValue(object)
+	}
+
+	if !ok {
+		return value{}, &evaluate.MissingVariableError{
+			Expr: prog.nodes[pc].(*expression.VarExpression),
+			Name: prog.vars[slot],
+		}
+	}
+
+	return v, nil
+}
+
+func negate(node expression.Expression, v value) (value, error) {
+	switch v.kind {
+	case intKind:
+		i, err := number.Negate(v.i)
+		if err != nil {
+			return value{}, &evaluate.OverflowError{
+				Expr:     node,
+				Operator: token.Minus,
+				Operands: []expression.Expression{v.expression()},
+			}
+		}
+
+		return intValue(i), nil
+	case floatKind:
+		return floatValue(-v.f), nil
+	default:
+		return value{}, newNumberMismatchError(node, v)
+	}
+}
+
+func call(fn function, stackArgs []value) (value, error) {
+	args := make([]interface{}, len(stackArgs))
+	for i, arg := range stackArgs {
+		args[i] = arg.goValue()
+	}
+
+	if err := fn.fn.CheckArgs(args); err != nil {
+		return value{}, err
+	}
+
+	object, err := fn.fn.Fn(args...)
+	if err != nil {
+		return value{}, fmt.Errorf("failed to call function %s: %w", fn.name, err)
+	}
+
+	result, err := valueOf(object)
+	if err != nil {
+		return value{}, fmt.Errorf("function %s return: %w", fn.name, err)
+	}
+
+	// kind of object such as int, []string is known after it is converted to value
+	if fn.fn.Result != evaluate.AnyKind && fn.fn.Result != result.evaluateKind() {
+		return value{}, fmt.Errorf("function %s expect return %s got %s", fn.name, fn.fn.Result, result.expression())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,46 @@
+package vm
+
+import (
+	"github.com/haunt98/evaluator/internal/set"
+)
+
+// newSet return set of constant array which is right of in, notin
+func newSet(arr []value) *set.Set {
+	s := set.New()
+
+	for _, child := range arr {
+		switch child.kind {
+		case boolKind:
+			s.AddBool(child.b)
+		case intKind:
+			s.AddInt(child.i)
+		case floatKind:
+			s.AddFloat(child.f)
+		case stringKind:
+			s.AddString(child.s)
+		case nullKind:
+			s.AddNull()
+		default:
+			// array is not equal to anything
+		}
+	}
+
+	return s
+}
+
+func contains(s *set.Set, v value) bool {
+	switch v.kind {
+	case boolKind:
+		return s.ContainsBool(v.b)
+	case intKind:
+		return s.ContainsInt(v.i)
+	case floatKind:
+		return s.ContainsFloat(v.f)
+	case stringKind:
+		return s.ContainsString(v.s)
+	case nullKind:
+		return s.ContainsNull()
+	default:
+		return false
+	}
+}
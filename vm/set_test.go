@@ -0,0 +1,196 @@
+package vm
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/haunt98/evaluator/evaluate"
+	"github.com/haunt98/evaluator/expression"
+	"github.com/haunt98/evaluator/internal/set"
+	"github.com/haunt98/evaluator/parser"
+	"github.com/haunt98/evaluator/token"
+	"github.com/stretchr/testify/assert"
+)
+
+// randomInCase is left in array of mixed literals
+type randomInCase struct {
+	operator token.Token
+	left     expression.Expression
+	right    *expression.ArrayExpression
+}
+
+func (randomInCase) Generate(r *rand.Rand, _ int) reflect.Value {
+	operators := []token.Token{token.In, token.NotIn}
+
+	children := make([]expression.Expression, set.MinSize+r.Intn(set.MinSize))
+	for i := range children {
+		children[i] = randomLiteral(r)
+	}
+
+	return reflect.ValueOf(randomInCase{
+		operator: operators[r.Intn(len(operators))],
+		left:     randomLiteral(r),
+		right:    expression.NewArrayExpression(children...),
+	})
+}
+
+func randomLiteral(r *rand.Rand) expression.Expression {
+	switch r.Intn(8) {
+	case 0:
+		return expression.NewBoolLiteral(r.Intn(2) == 0)
+	case 1:
+		return expression.NewIntLiteral(int64(r.Intn(7) - 3))
+	case 2:
+		// float which may be equal to int
+		return expression.NewFloatLiteral(float64(r.Intn(7)-3) / 2)
+	case 3:
+		specials := []float64{math.Copysign(0, -1), math.NaN(), math.Inf(1)}
+		return expression.NewFloatLiteral(specials[r.Intn(len(specials))])
+	case 4:
+		return expression.NewStringLiteral(fmt.Sprintf("%d", r.Intn(4)))
+	case 5:
+		return expression.NewArrayExpression(expression.NewIntLiteral(int64(r.Intn(3))))
+	case 6:
+		return expression.NewNullLiteral()
+	default:
+		// int as string so it is not equal to int
+		return expression.NewStringLiteral(fmt.Sprintf("%d", r.Intn(7)-3))
+	}
+}
+
+func TestSetSameAsVisitor(t *testing.T) {
+	f := func(tc randomInCase) bool {
+		// use var so left is not known when compile
+		expr := expression.NewBinaryExpression(tc.operator, expression.NewVarExpression("x"), tc.right)
+
+		left, err := evaluate.Value(tc.left)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+
+		args := map[string]interface{}{
+			"x": left,
+		}
+
+		visitResult, err := evaluate.NewVisitor(args).Visit(expr)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+
+		wantResult, err := evaluate.Value(visitResult)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+
+		prog, err := Compile(expr)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+
+		gotResult, err := prog.Run(args)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+
+		if wantResult != gotResult {
+			t.Logf("%s with x %v: expect %v got %v", expr, left, wantResult, gotResult)
+			return false
+		}
+
+		// set literal of visitor
+		setExpr := expression.NewBinaryExpression(tc.operator, expression.NewVarExpression("x"), expression.NewSetLiteral(tc.right.Children...))
+		setResult, err := evaluate.NewVisitor(args).Visit(setExpr)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+
+		if !assert.ObjectsAreEqual(visitResult, setResult) {
+			t.Logf("%s with x %v: expect %v got %v", setExpr, left, visitResult, setResult)
+			return false
+		}
+
+		return true
+	}
+
+	assert.NoError(t, quick.Check(f, &quick.Config{
+		MaxCount: 5000,
+	}))
+}
+
+func TestCompileSet(t *testing.T) {
+	prog := compile(t, "$x in [1, 2, 3]")
+	assert.Equal(t, "0: LoadVar 0\n1: Const 0\n2: In 0\n", prog.String())
+
+	prog = compile(t, `$x notin [1, 2, 3, 4, 5, 6, 7, "a"]`)
+	assert.Equal(t, "0: LoadVar 0\n1: NotInSet 0\n", prog.String())
+
+	// set literal of optimize
+	prog, err := Compile(expression.NewBinaryExpression(token.In,
+		expression.NewVarExpression("x"),
+		expression.NewSetLiteral(
+			expression.NewIntLiteral(1), expression.NewIntLiteral(2), expression.NewIntLiteral(3), expression.NewIntLiteral(4),
+			expression.NewIntLiteral(5), expression.NewIntLiteral(6), expression.NewIntLiteral(7), expression.NewIntLiteral(8),
+		),
+	))
+	assert.NoError(t, err)
+	assert.Equal(t, "0: LoadVar 0\n1: InSet 0\n", prog.String())
+
+	// var in array
+	prog = compile(t, "$x in [1, 2, 3, 4, 5, 6, 7, $y]")
+	assert.Equal(t, OpIn, prog.instructions[len(prog.instructions)-1].Op)
+}
+
+func newAllowList(n int) string {
+	input := "$x in ["
+	for i := 0; i < n; i++ {
+		if i != 0 {
+			input += ", "
+		}
+
+		input += fmt.Sprintf(`"user-%d"`, i)
+	}
+
+	return input + "]"
+}
+
+func BenchmarkSetVisitor(b *testing.B) {
+	expr, err := parser.NewParser(newAllowList(1000)).Parse()
+	assert.NoError(b, err)
+
+	args := map[string]interface{}{
+		"x": "user-999",
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := evaluate.NewVisitor(args).Visit(expr); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSetActivationRun(b *testing.B) {
+	prog := compile(b, newAllowList(1000))
+
+	act := prog.NewActivation()
+	act.SetString(slot(b, prog, "x"), "user-999")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := act.RunBool(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
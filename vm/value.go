@@ -0,0 +1,234 @@
+package vm
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/haunt98/evaluator/evaluate"
+	"github.com/haunt98/evaluator/expression"
+)
+
+type kind uint8
+
+const (
+	invalidKind kind = iota
+	boolKind
+	intKind
+	floatKind
+	stringKind
+	nullKind
+	arrayKind
+	// objectKind is go value which is not wrapped yet
+	// such as map, struct of member, index
+	objectKind
+)
+
+// value is tagged union on stack so evaluate does not allocate literal
+type value struct {
+	kind   kind
+	b      bool
+	i      int64
+	f      float64
+	s      string
+	arr    []value
+	object interface{}
+}
+
+func boolValue(b bool) value {
+	return value{kind: boolKind, b: b}
+}
+
+func intValue(i int64) value {
+	return value{kind: intKind, i: i}
+}
+
+func floatValue(f float64) value {
+	return value{kind: floatKind, f: f}
+}
+
+func stringValue(s string) value {
+	return value{kind: stringKind, s: s}
+}
+
+func nullValue() value {
+	return value{kind: nullKind}
+}
+
+func objectValue(object interface{}) value {
+	return value{kind: objectKind, object: object}
+}
+
+// valueOf wrap go value same as literal of evaluate
+func valueOf(v interface{}) (value, error) {
+	switch v := v.(type) {
+	case nil:
+		return nullValue(), nil
+	case bool:
+		return boolValue(v), nil
+	case int:
+		return intValue(int64(v)), nil
+	case int64:
+		return intValue(v), nil
+	case float32:
+		return floatValue(float64(v)), nil
+	case float64:
+		return floatValue(v), nil
+	case string:
+		return stringValue(v), nil
+	case []interface{}:
+		arr := make([]value, len(v))
+		for i, child := range v {
+			childValue, err := valueOf(child)
+			if err != nil {
+				return value{}, err
+			}
+
+			arr[i] = childValue
+		}
+
+		return value{kind: arrayKind, arr: arr}, nil
+	default:
+		return reflectValueOf(reflect.ValueOf(v))
+	}
+}
+
+func reflectValueOf(rv reflect.Value) (value, error) {
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nullValue(), nil
+		}
+
+		return valueOf(rv.Elem().Interface())
+	case reflect.Bool:
+		return boolValue(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intValue(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return value{}, fmt.Errorf("int overflow %d", rv.Uint())
+		}
+
+		return intValue(int64(rv.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return floatValue(rv.Float()), nil
+	case reflect.String:
+		return stringValue(rv.String()), nil
+	case reflect.Slice, reflect.Array:
+		arr := make([]value, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			childValue, err := valueOf(rv.Index(i).Interface())
+			if err != nil {
+				return value{}, err
+			}
+
+			arr[i] = childValue
+		}
+
+		return value{kind: arrayKind, arr: arr}, nil
+	default:
+		return value{}, fmt.Errorf("not implement var type %s", rv.Type())
+	}
+}
+
+// valueOfLiteral convert literal expression to value
+func valueOfLiteral(expr expression.Expression) (value, bool) {
+	switch e := expr.(type) {
+	case *expression.BoolLiteral:
+		return boolValue(e.Value), true
+	case *expression.IntLiteral:
+		return intValue(e.Value), true
+	case *expression.FloatLiteral:
+		return floatValue(e.Value), true
+	case *expression.StringLiteral:
+		return stringValue(e.Value), true
+	case *expression.NullLiteral:
+		return nullValue(), true
+	default:
+		return value{}, false
+	}
+}
+
+// goValue unwrap value same as Value of evaluate
+// object is returned as is
+func (v value) goValue() interface{} {
+	switch v.kind {
+	case boolKind:
+		return v.b
+	case intKind:
+		return v.i
+	case floatKind:
+		return v.f
+	case stringKind:
+		return v.s
+	case nullKind:
+		return nil
+	case arrayKind:
+		values := make([]interface{}, len(v.arr))
+		for i, child := range v.arr {
+			values[i] = child.goValue()
+		}
+
+		return values
+	default:
+		return v.object
+	}
+}
+
+// expression convert value back to expression
+// It is only used to return error so allocation is fine
+func (v value) expression() expression.Expression {
+	switch v.kind {
+	case boolKind:
+		return expression.NewBoolLiteral(v.b)
+	case intKind:
+		return expression.NewIntLiteral(v.i)
+	case floatKind:
+		return expression.NewFloatLiteral(v.f)
+	case stringKind:
+		return expression.NewStringLiteral(v.s)
+	case nullKind:
+		return expression.NewNullLiteral()
+	case arrayKind:
+		children := make([]expression.Expression, len(v.arr))
+		for i, child := range v.arr {
+			children[i] = child.expression()
+		}
+
+		return expression.NewArrayExpression(children...)
+	default:
+		return nil
+	}
+}
+
+// evaluateKind return kind of value same as evaluate.KindOf of goValue
+func (v value) evaluateKind() evaluate.Kind {
+	switch v.kind {
+	case boolKind:
+		return evaluate.BoolKind
+	case intKind:
+		return evaluate.IntKind
+	case floatKind:
+		return evaluate.FloatKind
+	case stringKind:
+		return evaluate.StringKind
+	case arrayKind:
+		return evaluate.ArrayKind
+	default:
+		return evaluate.AnyKind
+	}
+}
+
+func (v value) isNumber() bool {
+	return v.kind == intKind || v.kind == floatKind
+}
+
+// float return number as float64
+func (v value) float() float64 {
+	if v.kind == intKind {
+		return float64(v.i)
+	}
+
+	return v.f
+}